@@ -0,0 +1,19 @@
+// Package assets embeds the challenge datasets so tools and services can
+// read them without knowing where the repository is checked out.
+package assets
+
+import _ "embed"
+
+var (
+	// IntentsPreLoaded is intents_pre_loaded.csv, the 93 seed intents given
+	// to the teams.
+	//
+	//go:embed intents_pre_loaded.csv
+	IntentsPreLoaded []byte
+
+	// ExtraIntents is extra_intents.csv, the 80 intents the load test adds
+	// on top of the seed ones.
+	//
+	//go:embed extra_intents.csv
+	ExtraIntents []byte
+)
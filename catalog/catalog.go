@@ -0,0 +1,251 @@
+// Package catalog is the single source of truth for the 16 IVR services. It
+// is built from assets/intents_pre_loaded.csv, so the service table never
+// has to be copied by hand.
+package catalog
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"credsystem-hackathon/assets"
+)
+
+// Header is the first line of every intents CSV.
+var Header = []string{"service_id", "service_name", "intent"}
+
+// Default is the catalog of the challenge, read from the embedded
+// intents_pre_loaded.csv.
+var Default = MustParse(bytes.NewReader(assets.IntentsPreLoaded))
+
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e",
+	"í", "i", "ì", "i", "î", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c",
+)
+
+type (
+	// Service is one of the services an intent can be routed to.
+	Service struct {
+		ID   uint8  `json:"service_id"`
+		Name string `json:"service_name"`
+	}
+
+	// Intent is a labelled example sentence.
+	Intent struct {
+		Service
+		Text string `json:"intent"`
+	}
+
+	// Catalog holds the services and the intents they were read from.
+	Catalog struct {
+		services []Service
+		intents  []Intent
+		byID     map[uint8]Service
+		byKey    map[string]Service
+	}
+)
+
+// ReadIntents reads a "service_id;service_name;intent" CSV, checking the
+// header and the IDs but not the names.
+func ReadIntents(r io.Reader) ([]Intent, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = len(Header)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	if strings.Join(header, ";") != strings.Join(Header, ";") {
+		return nil, fmt.Errorf("invalid header %q, expected %q", strings.Join(header, ";"), strings.Join(Header, ";"))
+	}
+
+	var intents []Intent
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read intent: %w", err)
+		}
+
+		id, err := strconv.ParseUint(record[0], 10, 8)
+		if err != nil || id == 0 {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("line %d: invalid service_id %q", line, record[0])
+		}
+
+		intents = append(intents, Intent{
+			Service: Service{ID: uint8(id), Name: record[1]},
+			Text:    record[2],
+		})
+	}
+
+	return intents, nil
+}
+
+// Parse builds a catalog from an intents CSV. Every ID must always come
+// with the same name, and no two IDs may share a name.
+func Parse(r io.Reader) (*Catalog, error) {
+	intents, err := ReadIntents(r)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Catalog{
+		intents: intents,
+		byID:    make(map[uint8]Service),
+		byKey:   make(map[string]Service),
+	}
+
+	for _, intent := range intents {
+		if known, ok := c.byID[intent.ID]; ok {
+			if known.Name != intent.Name {
+				return nil, fmt.Errorf("service %d is named both %q and %q", intent.ID, known.Name, intent.Name)
+			}
+			continue
+		}
+
+		key := Normalize(intent.Name)
+		if known, ok := c.byKey[key]; ok {
+			return nil, fmt.Errorf("services %d and %d are both named %q", known.ID, intent.ID, intent.Name)
+		}
+
+		c.byID[intent.ID] = intent.Service
+		c.byKey[key] = intent.Service
+		c.services = append(c.services, intent.Service)
+	}
+
+	sort.Slice(c.services, func(i, j int) bool { return c.services[i].ID < c.services[j].ID })
+
+	return c, nil
+}
+
+// MustParse is like Parse but panics on error.
+func MustParse(r io.Reader) *Catalog {
+	c, err := Parse(r)
+	if err != nil {
+		panic(fmt.Sprintf("catalog: %v", err))
+	}
+
+	return c
+}
+
+// Services returns the services ordered by ID.
+func (c *Catalog) Services() []Service {
+	return append([]Service(nil), c.services...)
+}
+
+// Intents returns the example intents in file order.
+func (c *Catalog) Intents() []Intent {
+	return append([]Intent(nil), c.intents...)
+}
+
+// ByID returns the service with the given ID.
+func (c *Catalog) ByID(id uint8) (Service, bool) {
+	s, ok := c.byID[id]
+	return s, ok
+}
+
+// ByName returns the service with exactly the given name.
+func (c *Catalog) ByName(name string) (Service, bool) {
+	s, ok := c.byKey[Normalize(name)]
+	if !ok || s.Name != name {
+		return Service{}, false
+	}
+
+	return s, true
+}
+
+// Canonicalize maps a name that drifted from the catalog back to its
+// service. Case, accents, punctuation and spacing are ignored, and a name
+// that starts with a catalog name, like "Consulta do Saldo Conta do Mais",
+// maps to the longest such name. Names matching no service, like "Cartoes
+// de Credito", are rejected.
+func (c *Catalog) Canonicalize(name string) (Service, bool) {
+	key := Normalize(name)
+	if s, ok := c.byKey[key]; ok {
+		return s, true
+	}
+
+	var (
+		best    Service
+		bestLen int
+	)
+	for k, s := range c.byKey {
+		if strings.HasPrefix(key, k+" ") && len(k) > bestLen {
+			best, bestLen = s, len(k)
+		}
+	}
+
+	return best, bestLen > 0
+}
+
+// Check reports whether id and name designate the same service, as a
+// response of the find-service API must.
+func (c *Catalog) Check(id uint8, name string) error {
+	s, ok := c.ByID(id)
+	if !ok {
+		return fmt.Errorf("unknown service_id %d", id)
+	}
+	if s.Name != name {
+		return fmt.Errorf("service %d is named %q, got %q", id, s.Name, name)
+	}
+
+	return nil
+}
+
+// JSONSchema returns the JSON schema of a find-service data object
+// restricted to the catalog, suitable for structured output: service_id
+// must be a catalog ID and service_name a catalog name.
+func (c *Catalog) JSONSchema() map[string]any {
+	ids := make([]int, 0, len(c.services))
+	names := make([]string, 0, len(c.services))
+	for _, s := range c.services {
+		ids = append(ids, int(s.ID))
+		names = append(names, s.Name)
+	}
+
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"service_id": map[string]any{
+				"type": "integer",
+				"enum": ids,
+			},
+			"service_name": map[string]any{
+				"type": "string",
+				"enum": names,
+			},
+		},
+		"required":             []string{"service_id", "service_name"},
+		"additionalProperties": false,
+	}
+}
+
+// FoldAccents replaces the accented lowercase letters of s with their plain
+// form, leaving everything else as is.
+func FoldAccents(s string) string {
+	return accents.Replace(s)
+}
+
+// Normalize lowercases s, folds accents and reduces everything but letters
+// and digits to single spaces.
+func Normalize(s string) string {
+	words := strings.FieldsFunc(FoldAccents(strings.ToLower(s)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,189 @@
+package catalog
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		services int
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			input:    "service_id;service_name;intent\n12;Consulta do Saldo;qual meu saldo\n12;Consulta do Saldo;saldo\n7;Cancelamento de cartão;cancelar\n",
+			services: 2,
+		},
+		{
+			name:    "wrong header",
+			input:   "id;name;intent\n12;Consulta do Saldo;qual meu saldo\n",
+			wantErr: "invalid header",
+		},
+		{
+			name:    "wrong delimiter",
+			input:   "service_id,service_name,intent\n12,Consulta do Saldo,qual meu saldo\n",
+			wantErr: "wrong number of fields",
+		},
+		{
+			name:    "zero service_id",
+			input:   "service_id;service_name;intent\n0;Nenhum;qual meu saldo\n",
+			wantErr: `line 2: invalid service_id "0"`,
+		},
+		{
+			name:    "name drift",
+			input:   "service_id;service_name;intent\n12;Consulta do Saldo;saldo\n12;Consulta do Saldo Conta do Mais;saldo\n",
+			wantErr: "service 12 is named both",
+		},
+		{
+			name:    "shared name",
+			input:   "service_id;service_name;intent\n12;Consulta do Saldo;saldo\n13;consulta do saldo;saldo\n",
+			wantErr: "services 12 and 13 are both named",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Parse(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := len(c.Services()); got != tt.services {
+				t.Errorf("expected %d services, got %d", tt.services, got)
+			}
+		})
+	}
+}
+
+func TestDefault(t *testing.T) {
+	services := Default.Services()
+	if len(services) != 16 {
+		t.Fatalf("expected 16 services, got %d", len(services))
+	}
+
+	for i, s := range services {
+		if s.ID != uint8(i+1) {
+			t.Errorf("expected service %d at position %d, got %d", i+1, i, s.ID)
+		}
+	}
+
+	if got := len(Default.Intents()); got != 93 {
+		t.Errorf("expected 93 intents, got %d", got)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	saldo := Service{ID: 12, Name: "Consulta do Saldo"}
+
+	if got, ok := Default.ByID(12); !ok || got != saldo {
+		t.Errorf("expected %+v, got %+v", saldo, got)
+	}
+	if _, ok := Default.ByID(17); ok {
+		t.Error("expected service 17 to be unknown")
+	}
+
+	if got, ok := Default.ByName("Consulta do Saldo"); !ok || got != saldo {
+		t.Errorf("expected %+v, got %+v", saldo, got)
+	}
+	if _, ok := Default.ByName("consulta do saldo"); ok {
+		t.Error("expected ByName to be exact")
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantID uint8
+	}{
+		{name: "Consulta do Saldo", wantID: 12},
+		{name: "consulta do saldo", wantID: 12},
+		{name: "Consulta do Saldo Conta do Mais", wantID: 12},
+		{name: "Consulta do saldo conta do Mais", wantID: 12},
+		{name: "Cancelamento de cartao", wantID: 7},
+		{name: "  Reclamacoes ", wantID: 14},
+		{name: "consulta limite/vencimento do cartão/melhor dia de compra", wantID: 1},
+		{name: "Status de cartão", wantID: 5},
+		{name: "Status de Entrega do Cartao", wantID: 4},
+		{name: "Cartoes de Credito"},
+		{name: "Consulta"},
+		{name: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Default.Canonicalize(tt.name)
+			if tt.wantID == 0 {
+				if ok {
+					t.Errorf("expected no match, got %+v", got)
+				}
+				return
+			}
+
+			want, _ := Default.ByID(tt.wantID)
+			if !ok || got != want {
+				t.Errorf("expected %+v, got %+v", want, got)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if err := Default.Check(12, "Consulta do Saldo"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Default.Check(12, "Consulta do Saldo Conta do Mais"); err == nil {
+		t.Error("expected error for a drifted name, got nil")
+	}
+	if err := Default.Check(0, ""); err == nil {
+		t.Error("expected error for service 0, got nil")
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := json.Marshal(Default.JSONSchema())
+	if err != nil {
+		t.Fatalf("failed to marshal schema: %v", err)
+	}
+
+	var schema struct {
+		Properties struct {
+			ServiceID struct {
+				Enum []int `json:"enum"`
+			} `json:"service_id"`
+			ServiceName struct {
+				Enum []string `json:"enum"`
+			} `json:"service_name"`
+		} `json:"properties"`
+		Required []string `json:"required"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+
+	if got := len(schema.Properties.ServiceID.Enum); got != 16 {
+		t.Errorf("expected 16 IDs, got %d", got)
+	}
+	if got := schema.Properties.ServiceName.Enum[11]; got != "Consulta do Saldo" {
+		t.Errorf("expected Consulta do Saldo, got %s", got)
+	}
+	if want := []string{"service_id", "service_name"}; !reflect.DeepEqual(schema.Required, want) {
+		t.Errorf("expected required %v, got %v", want, schema.Required)
+	}
+}
+
+func TestFoldAccents(t *testing.T) {
+	if got, want := FoldAccents("Não recebi o cartão, cadê?"), "Nao recebi o cartao, cade?"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,393 @@
+package catalog
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"credsystem-hackathon/assets"
+
+	"ivr-service/client/openrouter"
+)
+
+// The tests below fail when a copy of the service table kept elsewhere in
+// the repository drifts from the catalog. Copies that cannot import this
+// package, because their Docker build context stops at their own
+// directory, are checked here instead.
+
+var readmeServiceRe = regexp.MustCompile(`(?m)^- (.+) \(ID (\d+)\)$`)
+
+func TestDriftReadme(t *testing.T) {
+	readme, err := os.ReadFile(filepath.Join("..", "README.md"))
+	if err != nil {
+		t.Fatalf("failed to read README: %v", err)
+	}
+
+	var got []Service
+	for _, m := range readmeServiceRe.FindAllSubmatch(readme, -1) {
+		id, _ := strconv.Atoi(string(m[2]))
+		got = append(got, Service{ID: uint8(id), Name: string(m[1])})
+	}
+
+	if want := Default.Services(); !reflect.DeepEqual(got, want) {
+		t.Errorf("README services drifted:\nexpected %v\ngot      %v", want, got)
+	}
+}
+
+func TestDriftExtraIntents(t *testing.T) {
+	extra, err := Parse(bytes.NewReader(assets.ExtraIntents))
+	if err != nil {
+		t.Fatalf("failed to parse extra_intents.csv: %v", err)
+	}
+
+	if got, want := extra.Services(), Default.Services(); !reflect.DeepEqual(got, want) {
+		t.Errorf("extra_intents.csv services drifted:\nexpected %v\ngot      %v", want, got)
+	}
+}
+
+func TestDriftExampleAssets(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "examples", "api", "assets", "intents_pre_loaded.csv"))
+	if err != nil {
+		t.Fatalf("failed to read example copy: %v", err)
+	}
+
+	if !bytes.Equal(data, assets.IntentsPreLoaded) {
+		t.Error("examples/api/assets/intents_pre_loaded.csv differs from assets/intents_pre_loaded.csv")
+	}
+}
+
+func TestDriftExampleCatalog(t *testing.T) {
+	path := filepath.Join("..", "examples", "api", "client", "openrouter", "catalog.go")
+
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", path, err)
+	}
+
+	got := serviceLiterals(declaration(file, "DefaultCatalog"))
+	if want := Default.Services(); !reflect.DeepEqual(got, want) {
+		t.Errorf("openrouter.DefaultCatalog drifted:\nexpected %v\ngot      %v", want, got)
+	}
+}
+
+func TestDriftExampleSchema(t *testing.T) {
+	got, err := json.Marshal(openrouter.DefaultCatalog.JSONSchema())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, err := json.Marshal(Default.JSONSchema())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("openrouter.Catalog.JSONSchema drifted:\nexpected %s\ngot      %s", want, got)
+	}
+}
+
+// participantCopies are the service tables the teams copied into their
+// submissions. Submissions are not edited to follow the catalog, so a copy
+// that drifted on purpose or by mistake is listed with knownDrift: the test
+// logs its drift and skips it instead of failing, and fails once the copy
+// matches again so the allowlist does not outlive the drift.
+var participantCopies = []struct {
+	name  string
+	path  string
+	parse func(path string) ([]Service, error)
+	// partial copies hold only some services, each of which must match
+	partial    bool
+	knownDrift string
+}{
+	{
+		name:  "herois-da-pilha util.ValidServices",
+		path:  filepath.Join("herois-da-pilha", "util", "types.go"),
+		parse: goServices("ValidServices", mapServices),
+	},
+	{
+		name:  "infinito-das-interfaces services",
+		path:  filepath.Join("infinito-das-interfaces", "main.go"),
+		parse: goServices("services", serviceLiterals),
+	},
+	{
+		name:  "cacadores-de-corrida validator.GetServiceName",
+		path:  filepath.Join("cacadores-de-corrida", "validator", "validator.go"),
+		parse: goServices("GetServiceName", mapServices),
+	},
+	{
+		name:       "piratas-do-pacote core.ServiceByID",
+		path:       filepath.Join("piratas-do-pacote", "core", "inference.go"),
+		parse:      goServices("ServiceByID", mapServices),
+		knownDrift: `service 12 is "Consulta do Saldo Conta do Mais"`,
+	},
+	{
+		name:       "piratas-do-pacote out.ServiceByID",
+		path:       filepath.Join("piratas-do-pacote", "adapter", "out", "ai_inferer.go"),
+		parse:      goServices("ServiceByID", mapServices),
+		knownDrift: `service 12 is "Consulta do Saldo Conta do Mais"`,
+	},
+	{
+		name:       "piratas-do-pacote kb.json",
+		path:       filepath.Join("piratas-do-pacote", "kb.json"),
+		parse:      knowledgeBaseServices,
+		knownDrift: `service 12 is "Consulta do Saldo Conta do Mais"`,
+	},
+	{
+		name:  "mavericks-do-mapa servicos_mcp.md",
+		path:  filepath.Join("mavericks-do-mapa", "prompttemplate", "servicos_mcp.md"),
+		parse: markdownServices,
+	},
+	{
+		name:       "mavericks-do-mapa StaticServiceGateway",
+		path:       filepath.Join("mavericks-do-mapa", "internal", "gateway", "service_gateway.go"),
+		parse:      goFileServices(inlineServices),
+		partial:    true,
+		knownDrift: "the offline keyword router answers with its own services, such as 1 \"Cartoes de Credito\"",
+	},
+}
+
+func TestDriftParticipants(t *testing.T) {
+	for _, tt := range participantCopies {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join("..", "participantes", tt.path)
+
+			got, err := tt.parse(path)
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", path, err)
+			}
+			if len(got) == 0 {
+				t.Fatalf("no service found in %s", path)
+			}
+
+			drift := serviceDrift(got, tt.partial)
+
+			switch {
+			case drift != "" && tt.knownDrift != "":
+				t.Logf("%s drifted:\n%s", tt.name, drift)
+				t.Skipf("known drift: %s", tt.knownDrift)
+			case drift != "":
+				t.Errorf("%s drifted:\n%s", tt.name, drift)
+			case tt.knownDrift != "":
+				t.Errorf("%s matches the catalog again, drop it from the known drifts", tt.name)
+			}
+		})
+	}
+}
+
+// serviceDrift describes how services differ from the catalog, or returns
+// an empty string when they match.
+func serviceDrift(services []Service, partial bool) string {
+	if !partial {
+		if want := Default.Services(); !reflect.DeepEqual(services, want) {
+			return fmt.Sprintf("expected %v\ngot      %v", want, services)
+		}
+		return ""
+	}
+
+	var drift []string
+	for _, s := range services {
+		if err := Default.Check(s.ID, s.Name); err != nil {
+			drift = append(drift, err.Error())
+		}
+	}
+	return strings.Join(drift, "\n")
+}
+
+// goServices reads the services of the package-level variable or function
+// name in a Go file with read.
+func goServices(name string, read func(ast.Node) []Service) func(string) ([]Service, error) {
+	return goFileServices(func(file *ast.File) []Service {
+		if decl := declaration(file, name); decl != nil {
+			return read(decl)
+		}
+		return nil
+	})
+}
+
+func goFileServices(read func(*ast.File) []Service) func(string) ([]Service, error) {
+	return func(path string) ([]Service, error) {
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		return read(file), nil
+	}
+}
+
+func knowledgeBaseServices(path string) ([]Service, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var kb struct {
+		Services []struct {
+			ID   uint8  `json:"id"`
+			Name string `json:"name"`
+		} `json:"services"`
+	}
+	if err := json.Unmarshal(data, &kb); err != nil {
+		return nil, err
+	}
+
+	services := make([]Service, len(kb.Services))
+	for i, s := range kb.Services {
+		services[i] = Service{ID: s.ID, Name: s.Name}
+	}
+	return services, nil
+}
+
+var markdownServiceRe = regexp.MustCompile(`(?m)^## Serviço (\d+) — (.+)$`)
+
+func markdownServices(path string) ([]Service, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []Service
+	for _, m := range markdownServiceRe.FindAllSubmatch(data, -1) {
+		id, _ := strconv.Atoi(string(m[1]))
+		services = append(services, Service{ID: uint8(id), Name: string(m[2])})
+	}
+	return services, nil
+}
+
+// declaration returns the package-level variable or function name of file.
+func declaration(file *ast.File, name string) ast.Node {
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Name.Name == name {
+				return d
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				if v, ok := spec.(*ast.ValueSpec); ok && len(v.Names) == 1 && v.Names[0].Name == name {
+					return v
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// serviceLiterals returns the {ID: ..., Name: ...} or {id, "name"} elements
+// of the first composite literal in node.
+func serviceLiterals(node ast.Node) []Service {
+	var services []Service
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		list, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+
+		for _, elt := range list.Elts {
+			if lit, ok := elt.(*ast.CompositeLit); ok {
+				services = append(services, serviceLiteral(lit))
+			}
+		}
+
+		return false
+	})
+
+	return services
+}
+
+// mapServices returns the entries of the first map[int]string literal in
+// node, ordered by ID.
+func mapServices(node ast.Node) []Service {
+	var services []Service
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		list, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+
+		for _, elt := range list.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, _ := kv.Key.(*ast.BasicLit)
+			value, _ := kv.Value.(*ast.BasicLit)
+			if key == nil || value == nil {
+				continue
+			}
+
+			id, _ := strconv.Atoi(key.Value)
+			name, _ := strconv.Unquote(value.Value)
+			services = append(services, Service{ID: uint8(id), Name: name})
+		}
+
+		return false
+	})
+
+	slices.SortFunc(services, func(a, b Service) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return services
+}
+
+// inlineServices returns every {ID: ..., Name: ...} composite literal found
+// anywhere in file.
+func inlineServices(file *ast.File) []Service {
+	var services []Service
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		if s := serviceLiteral(lit); s.ID != 0 && s.Name != "" {
+			services = append(services, s)
+		}
+		return true
+	})
+
+	return services
+}
+
+// serviceLiteral reads the ID and Name of a keyed {ID: ..., Name: ...} or
+// positional {id, "name"} composite literal.
+func serviceLiteral(lit *ast.CompositeLit) Service {
+	var s Service
+	for _, field := range lit.Elts {
+		key := ""
+		if kv, ok := field.(*ast.KeyValueExpr); ok {
+			ident, _ := kv.Key.(*ast.Ident)
+			if ident == nil {
+				continue
+			}
+			key, field = ident.Name, kv.Value
+		}
+
+		value, _ := field.(*ast.BasicLit)
+		if value == nil {
+			continue
+		}
+
+		switch {
+		case key == "ID" || key == "" && value.Kind == token.INT:
+			id, _ := strconv.Atoi(value.Value)
+			s.ID = uint8(id)
+		case key == "Name" || key == "" && value.Kind == token.STRING:
+			s.Name, _ = strconv.Unquote(value.Value)
+		}
+	}
+	return s
+}
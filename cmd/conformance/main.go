@@ -0,0 +1,55 @@
+// Command conformance runs the find-service contract checks against a
+// running implementation and prints one line per check.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"credsystem-hackathon/conformance"
+)
+
+func main() {
+	output := flag.String("output", "", "Also write the results as JSON to this file")
+	concurrency := flag.Int("concurrency", conformance.DefaultConcurrency, "Requests sent at once by the concurrency check")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: conformance [flags] <base_url>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	results := conformance.Verify(context.Background(), flag.Arg(0), conformance.Config{Concurrency: *concurrency})
+
+	for _, r := range results {
+		status := "PASS"
+		if !r.Passed {
+			status = "FAIL"
+		}
+		fmt.Printf("%s %-18s %s\n", status, r.Check, r.Message)
+	}
+
+	if *output != "" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			fmt.Printf("Error marshaling results: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := os.WriteFile(*output, data, 0o644); err != nil {
+			fmt.Printf("Error saving results: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if !conformance.Passed(results) {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,479 @@
+// Command datasets validates, deduplicates, merges and splits intent CSVs.
+//
+// Usage:
+//
+//	datasets validate <file>...
+//	datasets dedup [-near 0.8] [-o out.csv] <file>
+//	datasets balance <file>...
+//	datasets merge [-o out.csv] <file>...
+//	datasets split [-test 0.2] [-seed 1] -train train.csv -eval eval.csv <file>...
+//	datasets leakage [-near 0.8] -eval <file> <train file>...
+//	datasets generate [-n 5] [-typos 0.2] [-registers neutral,polite,informal] [-seed 1] [-o out.csv] [<seed file>...]
+//	datasets expand [-services 2,3] [-n 5] [-negatives 3] [-model m] [-base-url url] [-mock] [-queue review_queue.jsonl] [-dataset versions]
+//	datasets review [-addr localhost:8090] [-queue review_queue.jsonl] [-dataset versions]
+//
+// CSV output goes to stdout unless -o is given; reports go to stderr.
+//
+// expand asks OpenRouter, with the key in OPENROUTER_API_KEY and the model
+// in -model or OPENROUTER_MODEL, for paraphrases of every service and hard
+// negatives between the confusable services, and appends them to a review
+// queue. review serves a web UI to accept or reject the queued items and
+// publish the accepted ones as the next intents_vN.csv in the dataset
+// directory, the first version starting from the embedded pre-loaded
+// intents.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"credsystem-hackathon/catalog"
+	"credsystem-hackathon/dataset"
+	"credsystem-hackathon/expand"
+
+	"ivr-service/client/openrouter"
+)
+
+const (
+	defaultQueue      = "review_queue.jsonl"
+	defaultDatasetDir = "versions"
+	expandTimeout     = 60 * time.Second
+)
+
+// errFailed makes the command exit with status 1 after it printed why.
+var errFailed = errors.New("failed")
+
+var commands = map[string]func(args []string) error{
+	"validate": runValidate,
+	"dedup":    runDedup,
+	"balance":  runBalance,
+	"merge":    runMerge,
+	"split":    runSplit,
+	"leakage":  runLeakage,
+	"generate": runGenerate,
+	"expand":   runExpand,
+	"review":   runReview,
+}
+
+func main() {
+	if len(os.Args) < 2 || commands[os.Args[1]] == nil {
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Fprintf(os.Stderr, "Usage: datasets <%s> [flags] <file>...\n", strings.Join(names, "|"))
+		os.Exit(2)
+	}
+
+	if err := commands[os.Args[1]](os.Args[2:]); err != nil {
+		if !errors.Is(err, errFailed) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func runValidate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return errors.New("no file given")
+	}
+
+	failed := false
+	for _, path := range fs.Args() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		issues := dataset.Validate(data, catalog.Default)
+		for _, issue := range issues {
+			fmt.Printf("%s: %s\n", path, issue)
+		}
+		if dataset.HasErrors(issues) {
+			failed = true
+		} else {
+			fmt.Printf("%s: ok\n", path)
+		}
+	}
+
+	if failed {
+		return errFailed
+	}
+
+	return nil
+}
+
+func runDedup(args []string) error {
+	fs := flag.NewFlagSet("dedup", flag.ExitOnError)
+	near := fs.Float64("near", dataset.DefaultNearThreshold, "Similarity from which intents are near duplicates, 1 for exact duplicates only")
+	output := fs.String("o", "", "Output file, stdout by default")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("expected exactly one file")
+	}
+
+	intents, err := dataset.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	kept, duplicates := dataset.Dedup(intents, *near)
+	for _, dup := range duplicates {
+		kind := "dropped"
+		if dup.Conflict {
+			kind = fmt.Sprintf("CONFLICT %d vs %d", dup.Intent.ID, dup.Original.ID)
+		}
+		fmt.Fprintf(os.Stderr, "%s (%.2f): %q ~ %q\n", kind, dup.Similarity, dup.Intent.Text, dup.Original.Text)
+	}
+	fmt.Fprintf(os.Stderr, "kept %d of %d intents\n", len(kept), len(intents))
+
+	return writeIntents(*output, kept)
+}
+
+func runBalance(args []string) error {
+	fs := flag.NewFlagSet("balance", flag.ExitOnError)
+	fs.Parse(args)
+
+	intents, err := readFiles(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	balance := dataset.Balance(intents, catalog.Default)
+
+	var lowest, highest int
+	for i, class := range balance {
+		fmt.Printf("%3d  %5d  %5.1f%%  %s\n", class.ID, class.Count, class.Share*100, class.Name)
+		if class.Count < balance[lowest].Count {
+			lowest = i
+		}
+		if class.Count > balance[highest].Count {
+			highest = i
+		}
+	}
+
+	fmt.Printf("total %d intents, largest class %d (%d), smallest class %d (%d)\n",
+		len(intents), balance[highest].ID, balance[highest].Count, balance[lowest].ID, balance[lowest].Count)
+
+	return nil
+}
+
+func runMerge(args []string) error {
+	fs := flag.NewFlagSet("merge", flag.ExitOnError)
+	output := fs.String("o", "", "Output file, stdout by default")
+	fs.Parse(args)
+
+	var sets [][]catalog.Intent
+	for _, path := range fs.Args() {
+		intents, err := dataset.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sets = append(sets, intents)
+	}
+	if len(sets) == 0 {
+		return errors.New("no file given")
+	}
+
+	merged, rejected := dataset.Merge(catalog.Default, sets...)
+	for _, intent := range rejected {
+		fmt.Fprintf(os.Stderr, "rejected unknown service_id %d: %q\n", intent.ID, intent.Text)
+	}
+	fmt.Fprintf(os.Stderr, "merged %d intents\n", len(merged))
+
+	return writeIntents(*output, merged)
+}
+
+func runSplit(args []string) error {
+	fs := flag.NewFlagSet("split", flag.ExitOnError)
+	testFraction := fs.Float64("test", 0.2, "Fraction of every service going to the eval set")
+	seed := fs.Uint64("seed", 1, "Random seed, the same seed gives the same split")
+	trainPath := fs.String("train", "", "Training set output file")
+	evalPath := fs.String("eval", "", "Eval set output file")
+	fs.Parse(args)
+
+	if *trainPath == "" || *evalPath == "" {
+		return errors.New("-train and -eval are required")
+	}
+	if *testFraction < 0 || *testFraction > 1 {
+		return fmt.Errorf("-test must be between 0 and 1, got %v", *testFraction)
+	}
+
+	intents, err := readFiles(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	train, eval := dataset.Split(intents, *testFraction, *seed)
+	if err := dataset.WriteFile(*trainPath, train); err != nil {
+		return err
+	}
+	if err := dataset.WriteFile(*evalPath, eval); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "train %d intents, eval %d intents\n", len(train), len(eval))
+
+	return nil
+}
+
+func runLeakage(args []string) error {
+	fs := flag.NewFlagSet("leakage", flag.ExitOnError)
+	near := fs.Float64("near", dataset.DefaultNearThreshold, "Similarity from which an eval intent counts as leaked")
+	evalPath := fs.String("eval", "", "Eval set, e.g. assets/extra_intents.csv")
+	fs.Parse(args)
+
+	if *evalPath == "" {
+		return errors.New("-eval is required")
+	}
+
+	eval, err := dataset.ReadFile(*evalPath)
+	if err != nil {
+		return err
+	}
+
+	train, err := readFiles(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	leaks := dataset.Leakage(train, eval, *near)
+	for _, leak := range leaks {
+		fmt.Printf("%.2f  %q ~ %q\n", leak.Similarity, leak.Eval.Text, leak.Train.Text)
+	}
+	fmt.Printf("%d of %d eval intents leaked into the training files\n", len(leaks), len(eval))
+
+	if len(leaks) > 0 {
+		return errFailed
+	}
+
+	return nil
+}
+
+func runGenerate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	perSeed := fs.Int("n", dataset.DefaultVariantsPerSeed, "Variants generated per seed intent")
+	typoRate := fs.Float64("typos", dataset.DefaultTypoRate, "Fraction of variants given a typo")
+	registers := fs.String("registers", "neutral,polite,informal", "Comma separated registers to generate")
+	seed := fs.Uint64("seed", 1, "Random seed, the same seed gives the same variants")
+	output := fs.String("o", "", "Output file, stdout by default")
+	fs.Parse(args)
+
+	opts := dataset.GenerateOptions{VariantsPerSeed: *perSeed, TypoRate: *typoRate, Seed: *seed}
+	for _, name := range strings.Split(*registers, ",") {
+		register := dataset.Register(strings.TrimSpace(name))
+		switch register {
+		case dataset.RegisterNeutral, dataset.RegisterPolite, dataset.RegisterInformal:
+			opts.Registers = append(opts.Registers, register)
+		default:
+			return fmt.Errorf("unknown register %q", name)
+		}
+	}
+
+	// the embedded pre-loaded intents are the default seeds
+	seeds := catalog.Default.Intents()
+	if fs.NArg() > 0 {
+		var err error
+		if seeds, err = readFiles(fs.Args()); err != nil {
+			return err
+		}
+	}
+
+	variants := dataset.Generate(seeds, opts)
+	fmt.Fprintf(os.Stderr, "generated %d variants from %d seeds\n", len(variants), len(seeds))
+
+	return writeIntents(*output, variants)
+}
+
+func runExpand(args []string) error {
+	fs := flag.NewFlagSet("expand", flag.ExitOnError)
+	services := fs.String("services", "", "Comma separated service IDs to expand, all by default")
+	paraphrases := fs.Int("n", expand.DefaultParaphrases, "Paraphrases asked per service")
+	negatives := fs.Int("negatives", expand.DefaultHardNegatives, "Hard negatives asked per confusable service, 0 to skip them")
+	model := fs.String("model", os.Getenv("OPENROUTER_MODEL"), "OpenRouter model, OPENROUTER_MODEL by default")
+	baseURL := fs.String("base-url", "https://openrouter.ai/api/v1", "OpenRouter API base URL")
+	mock := fs.Bool("mock", false, "Use the built-in mock router instead of OpenRouter, no API key needed")
+	queuePath := fs.String("queue", defaultQueue, "Review queue file")
+	dir := fs.String("dataset", defaultDatasetDir, "Directory of the dataset versions")
+	fs.Parse(args)
+
+	var opts []openrouter.Option
+	switch apiKey := os.Getenv("OPENROUTER_API_KEY"); {
+	case *mock:
+		srv := httptest.NewServer(expand.MockRouter())
+		defer srv.Close()
+		*baseURL = srv.URL
+		if *model == "" {
+			*model = expand.MockModel
+		}
+	case apiKey == "":
+		return errors.New("OPENROUTER_API_KEY is not set, use -mock to try the command without it")
+	case *model == "":
+		return errors.New("no model set, use -model or OPENROUTER_MODEL, or -mock to try the command without it")
+	default:
+		opts = append(opts, openrouter.WithAuth(apiKey))
+	}
+	opts = append(opts, openrouter.WithModels(*model), openrouter.WithTimeout(expandTimeout), openrouter.WithRetry(openrouter.RetryPolicy{}))
+
+	ids, err := parseServices(*services)
+	if err != nil {
+		return err
+	}
+
+	queue, err := expand.OpenQueue(*queuePath)
+	if err != nil {
+		return err
+	}
+
+	_, known, err := expand.Latest(*dir, catalog.Default.Intents())
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	g := expand.NewGenerator(openrouter.NewClient(*baseURL, opts...), catalog.Default)
+
+	type job struct {
+		name     string
+		generate func() ([]expand.Item, error)
+	}
+
+	var jobs []job
+	for _, id := range ids {
+		jobs = append(jobs, job{
+			name:     fmt.Sprintf("paraphrases of %d", id),
+			generate: func() ([]expand.Item, error) { return g.Paraphrases(ctx, id, *paraphrases) },
+		})
+	}
+	if *negatives > 0 {
+		for _, pair := range expand.DefaultConfusables {
+			for _, p := range [][2]uint8{{pair.A, pair.B}, {pair.B, pair.A}} {
+				if !slices.Contains(ids, p[0]) {
+					continue
+				}
+				jobs = append(jobs, job{
+					name:     fmt.Sprintf("hard negatives of %d like %d", p[0], p[1]),
+					generate: func() ([]expand.Item, error) { return g.HardNegatives(ctx, p[0], p[1], *negatives) },
+				})
+			}
+		}
+	}
+
+	// every answer is queued right away, so that a failure or an interrupt
+	// keeps what was generated so far
+	failed, total := false, 0
+	for _, j := range jobs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		items, err := j.generate()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", j.name, err)
+			failed = true
+			continue
+		}
+
+		added, err := queue.Add(expand.Fresh(items, known))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "%s: %d generated, %d queued\n", j.name, len(items), added)
+		total += added
+	}
+	fmt.Fprintf(os.Stderr, "queued %d items in %s\n", total, *queuePath)
+
+	if failed {
+		return errFailed
+	}
+
+	return nil
+}
+
+func runReview(args []string) error {
+	fs := flag.NewFlagSet("review", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8090", "Address the review UI listens on")
+	queuePath := fs.String("queue", defaultQueue, "Review queue file")
+	dir := fs.String("dataset", defaultDatasetDir, "Directory of the dataset versions")
+	fs.Parse(args)
+
+	queue, err := expand.OpenQueue(*queuePath)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("reviewing %s on http://%s", *queuePath, *addr)
+
+	return http.ListenAndServe(*addr, expand.NewReviewHandler(queue, *dir, catalog.Default.Intents()))
+}
+
+// parseServices parses a comma separated list of service IDs, every
+// catalog service when the list is empty.
+func parseServices(list string) ([]uint8, error) {
+	var ids []uint8
+	if list == "" {
+		for _, s := range catalog.Default.Services() {
+			ids = append(ids, s.ID)
+		}
+		return ids, nil
+	}
+
+	for _, field := range strings.Split(list, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(field), 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service ID %q", field)
+		}
+		if _, ok := catalog.Default.ByID(uint8(id)); !ok {
+			return nil, fmt.Errorf("unknown service_id %d", id)
+		}
+		ids = append(ids, uint8(id))
+	}
+
+	return ids, nil
+}
+
+func readFiles(paths []string) ([]catalog.Intent, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("no file given")
+	}
+
+	var all []catalog.Intent
+	for _, path := range paths {
+		intents, err := dataset.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, intents...)
+	}
+
+	return all, nil
+}
+
+func writeIntents(path string, intents []catalog.Intent) error {
+	if path != "" {
+		return dataset.WriteFile(path, intents)
+	}
+
+	return dataset.Write(os.Stdout, intents)
+}
@@ -0,0 +1,449 @@
+// Package conformance checks that a find-service implementation honours the
+// API contract of the challenge README. It runs against any base URL, so it
+// backs both the load-test runner and the teams' own go tests.
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"credsystem-hackathon/catalog"
+)
+
+const (
+	FindServicePath = "/api/find-service"
+	HealthzPath     = "/api/healthz"
+
+	DefaultTimeout        = 20 * time.Second
+	DefaultConcurrency    = 20
+	DefaultLargeBodyBytes = 1 << 20
+)
+
+type (
+	// Config tunes Verify. Zero values fall back to the defaults.
+	Config struct {
+		// Client sends the requests, with a DefaultTimeout timeout by default.
+		Client *http.Client
+		// Catalog validates the services answered, catalog.Default by default.
+		Catalog *catalog.Catalog
+		// Concurrency is how many requests the concurrency check sends at
+		// once.
+		Concurrency int
+		// LargeBodyBytes is the size of the intent sent by the large body
+		// check.
+		LargeBodyBytes int
+	}
+
+	// Result is the outcome of one check.
+	Result struct {
+		Check   string `json:"check"`
+		Passed  bool   `json:"passed"`
+		Message string `json:"message,omitempty"`
+	}
+
+	// Check is one rule of the contract.
+	Check struct {
+		Name        string
+		Description string
+		Run         func(ctx context.Context, s *Session) error
+	}
+
+	// Session holds what a check needs to talk to the implementation.
+	Session struct {
+		BaseURL string
+		Config  Config
+	}
+
+	// FindServiceResponse is the decoded find-service response body.
+	FindServiceResponse struct {
+		Success bool             `json:"success"`
+		Data    *catalog.Service `json:"data"`
+		Error   string           `json:"error"`
+	}
+)
+
+// ErrNoIntents fails the checks that need an intent to send when
+// Config.Catalog has none.
+var ErrNoIntents = errors.New("the catalog has no intents to send")
+
+// Checks lists the contract rules in the order Verify runs them.
+var Checks = []Check{
+	{Name: "healthz", Description: `GET /api/healthz answers 200 with {"status":"ok"}`, Run: checkHealthz},
+	{Name: "post-only", Description: "find-service rejects other methods with 405", Run: checkPostOnly},
+	{Name: "response-shape", Description: "find-service answers JSON with success, data{service_id, service_name} and error", Run: checkResponseShape},
+	{Name: "success-semantics", Description: "success is true only with a catalog service, and false only with an error", Run: checkSuccessSemantics},
+	{Name: "empty-intent", Description: "an empty or missing intent is never a success", Run: checkEmptyIntent},
+	{Name: "invalid-json", Description: "a malformed body is answered 400 with success false", Run: checkInvalidJSON},
+	{Name: "unknown-fields", Description: "unknown request fields are ignored", Run: checkUnknownFields},
+	{Name: "large-body", Description: "a large body is answered without a server error", Run: checkLargeBody},
+	{Name: "concurrency", Description: "concurrent requests all get valid answers", Run: checkConcurrency},
+}
+
+// Verify runs every check against the implementation listening at baseURL,
+// e.g. "http://localhost:18020".
+func Verify(ctx context.Context, baseURL string, cfg Config) []Result {
+	s := &Session{BaseURL: strings.TrimSuffix(baseURL, "/"), Config: cfg.withDefaults()}
+
+	results := make([]Result, 0, len(Checks))
+	for _, check := range Checks {
+		result := Result{Check: check.Name, Passed: true}
+		if err := check.Run(ctx, s); err != nil {
+			result.Passed, result.Message = false, err.Error()
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// Passed reports whether every result passed.
+func Passed(results []Result) bool {
+	for _, r := range results {
+		if !r.Passed {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c Config) withDefaults() Config {
+	if c.Client == nil {
+		c.Client = &http.Client{Timeout: DefaultTimeout}
+	}
+	if c.Catalog == nil {
+		c.Catalog = catalog.Default
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = DefaultConcurrency
+	}
+	if c.LargeBodyBytes <= 0 {
+		c.LargeBodyBytes = DefaultLargeBodyBytes
+	}
+
+	return c
+}
+
+// Intents returns the intents of the configured catalog, or ErrNoIntents
+// when it has none.
+func (s *Session) Intents() ([]catalog.Intent, error) {
+	intents := s.Config.Catalog.Intents()
+	if len(intents) == 0 {
+		return nil, ErrNoIntents
+	}
+
+	return intents, nil
+}
+
+// Do sends a request to path and returns the response with its body read.
+func (s *Session) Do(ctx context.Context, method, path string, body []byte) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.BaseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %v", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.Config.Client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s %s: failed to read body: %w", method, path, err)
+	}
+
+	return resp, data, nil
+}
+
+// FindService posts body to find-service and decodes the answer, checking
+// its shape and the success semantics.
+func (s *Session) FindService(ctx context.Context, body []byte) (int, *FindServiceResponse, error) {
+	resp, data, err := s.Do(ctx, http.MethodPost, FindServicePath, body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	res, err := s.decode(resp, data)
+	if err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("status %d: %w", resp.StatusCode, err)
+	}
+
+	return resp.StatusCode, res, nil
+}
+
+// decode validates a find-service response. Fields are checked one by one so
+// that a wrong type, like a string service_id, is reported as such.
+func (s *Session) decode(resp *http.Response, data []byte) (*FindServiceResponse, error) {
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "application/json" {
+		return nil, fmt.Errorf("expected Content-Type application/json, got %q", resp.Header.Get("Content-Type"))
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("body is not a JSON object: %v. body: %s", err, truncate(data))
+	}
+
+	var res FindServiceResponse
+
+	raw, ok := fields["success"]
+	if !ok {
+		return nil, errors.New(`missing "success"`)
+	}
+	if err := json.Unmarshal(raw, &res.Success); err != nil {
+		return nil, fmt.Errorf(`"success" must be a bool, got %s`, raw)
+	}
+
+	if raw, ok := fields["error"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &res.Error); err != nil {
+			return nil, fmt.Errorf(`"error" must be a string, got %s`, raw)
+		}
+	}
+
+	if raw, ok := fields["data"]; ok && string(raw) != "null" {
+		var service struct {
+			ID   *uint8  `json:"service_id"`
+			Name *string `json:"service_name"`
+		}
+		if err := json.Unmarshal(raw, &service); err != nil {
+			return nil, fmt.Errorf(`"data" must be {"service_id": int, "service_name": string}, got %s`, raw)
+		}
+		if service.ID != nil && service.Name != nil {
+			res.Data = &catalog.Service{ID: *service.ID, Name: *service.Name}
+		}
+	}
+
+	if res.Success {
+		if res.Data == nil {
+			return nil, errors.New(`success is true without "data"`)
+		}
+		if err := s.Config.Catalog.Check(res.Data.ID, res.Data.Name); err != nil {
+			return nil, fmt.Errorf("success is true with an invalid service: %w", err)
+		}
+		if res.Error != "" {
+			return nil, fmt.Errorf("success is true with error %q", res.Error)
+		}
+	} else if res.Error == "" {
+		return nil, errors.New("success is false without an error message")
+	}
+
+	return &res, nil
+}
+
+func checkHealthz(ctx context.Context, s *Session) error {
+	resp, data, err := s.Do(ctx, http.MethodGet, HealthzPath, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil || body.Status != "ok" {
+		return fmt.Errorf(`expected {"status":"ok"}, got %s`, truncate(data))
+	}
+
+	return nil
+}
+
+func checkPostOnly(ctx context.Context, s *Session) error {
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		resp, _, err := s.Do(ctx, method, FindServicePath, nil)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusMethodNotAllowed {
+			return fmt.Errorf("%s: expected status 405, got %d", method, resp.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+func checkResponseShape(ctx context.Context, s *Session) error {
+	intents, err := s.Intents()
+	if err != nil {
+		return err
+	}
+
+	status, _, err := s.FindService(ctx, intentBody(intents[0].Text))
+	if err != nil {
+		return err
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("expected status 200, got %d", status)
+	}
+
+	return nil
+}
+
+// checkSuccessSemantics sends one seed intent per service. Wrong answers
+// are allowed, the accuracy is what the load test measures; inconsistent
+// ones are not.
+func checkSuccessSemantics(ctx context.Context, s *Session) error {
+	intents, err := s.Intents()
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[uint8]bool)
+	for _, intent := range intents {
+		if seen[intent.ID] {
+			continue
+		}
+		seen[intent.ID] = true
+
+		if _, _, err := s.FindService(ctx, intentBody(intent.Text)); err != nil {
+			return fmt.Errorf("intent %q: %w", intent.Text, err)
+		}
+	}
+
+	return nil
+}
+
+func checkEmptyIntent(ctx context.Context, s *Session) error {
+	for _, body := range []string{`{"intent": ""}`, `{"intent": "   "}`, `{}`} {
+		status, res, err := s.FindService(ctx, []byte(body))
+		if err != nil {
+			return fmt.Errorf("%s: %w", body, err)
+		}
+
+		if res.Success {
+			return fmt.Errorf("%s: expected success false, got a service", body)
+		}
+		if status >= http.StatusInternalServerError {
+			return fmt.Errorf("%s: expected status 200 or 4xx, got %d", body, status)
+		}
+	}
+
+	return nil
+}
+
+func checkInvalidJSON(ctx context.Context, s *Session) error {
+	for _, body := range []string{`{"intent":`, `intent=saldo`, `["saldo"]`} {
+		status, _, err := s.FindService(ctx, []byte(body))
+		if err != nil {
+			return fmt.Errorf("%s: %w", body, err)
+		}
+
+		if status != http.StatusBadRequest {
+			return fmt.Errorf("%s: expected status 400, got %d", body, status)
+		}
+	}
+
+	return nil
+}
+
+func checkUnknownFields(ctx context.Context, s *Session) error {
+	intents, err := s.Intents()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"intent":  intents[0].Text,
+		"channel": "ura",
+		"context": map[string]any{"attempt": 1},
+	})
+	if err != nil {
+		return err
+	}
+
+	status, _, err := s.FindService(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("expected status 200, got %d", status)
+	}
+
+	return nil
+}
+
+// checkLargeBody accepts either an answer or a rejection such as 413, but
+// not a server error, a non-JSON answer or a hang.
+func checkLargeBody(ctx context.Context, s *Session) error {
+	resp, data, err := s.Do(ctx, http.MethodPost, FindServicePath, intentBody(strings.Repeat("saldo ", s.Config.LargeBodyBytes/6)))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("expected status 200 or 4xx, got %d", resp.StatusCode)
+	}
+	if resp.StatusCode == http.StatusOK {
+		if _, err := s.decode(resp, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkConcurrency(ctx context.Context, s *Session) error {
+	intents, err := s.Intents()
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for i := range s.Config.Concurrency {
+		intent := intents[i%len(intents)].Text
+
+		wg.Go(func() {
+			status, _, err := s.FindService(ctx, intentBody(intent))
+			if err == nil && status != http.StatusOK {
+				err = fmt.Errorf("expected status 200, got %d", status)
+			}
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("intent %q: %w", intent, err))
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d concurrent requests failed, first: %w", len(errs), s.Config.Concurrency, errs[0])
+	}
+
+	return nil
+}
+
+func intentBody(intent string) []byte {
+	body, _ := json.Marshal(map[string]string{"intent": intent})
+	return body
+}
+
+func truncate(data []byte) string {
+	const limit = 200
+	if len(data) > limit {
+		return string(data[:limit]) + "..."
+	}
+
+	return string(data)
+}
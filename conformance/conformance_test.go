@@ -0,0 +1,210 @@
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"credsystem-hackathon/catalog"
+)
+
+// reference is a minimal implementation honouring the contract. Its
+// behaviour can be broken one rule at a time through the flags.
+type reference struct {
+	stringID      bool
+	emptySuccess  bool
+	allowGet      bool
+	plainText     bool
+	strictFields  bool
+	panicOnLarge  bool
+	driftedName   bool
+	successNoData bool
+}
+
+func (h reference) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == HealthzPath && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	case r.URL.Path == FindServicePath && (r.Method == http.MethodPost || h.allowGet && r.Method == http.MethodGet):
+		h.findService(w, r)
+	case r.URL.Path == FindServicePath:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h reference) findService(w http.ResponseWriter, r *http.Request) {
+	if h.panicOnLarge && r.ContentLength > 1<<16 {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var req struct {
+		Intent string `json:"intent"`
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16))
+	if h.strictFields {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]any{"success": false, "error": "invalid request body"})
+		return
+	}
+
+	if strings.TrimSpace(req.Intent) == "" && !h.emptySuccess {
+		writeJSON(w, http.StatusBadRequest, map[string]any{"success": false, "error": "intent is required"})
+		return
+	}
+
+	service := catalog.Default.Services()[0]
+	for _, intent := range catalog.Default.Intents() {
+		if intent.Text == req.Intent {
+			service = intent.Service
+		}
+	}
+
+	data := map[string]any{"service_id": service.ID, "service_name": service.Name}
+	if h.stringID {
+		data["service_id"] = "12"
+	}
+	if h.driftedName {
+		data["service_name"] = service.Name + " Conta do Mais"
+	}
+	if h.successNoData {
+		data = nil
+	}
+
+	if h.plainText {
+		w.Write([]byte("ok"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"success": true, "data": data})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func TestRunHandler(t *testing.T) {
+	RunHandler(t, reference{}, Config{})
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler reference
+		failing []string
+	}{
+		{
+			name: "conforming",
+		},
+		{
+			name:    "string service_id",
+			handler: reference{stringID: true},
+			failing: []string{"response-shape", "success-semantics", "unknown-fields", "concurrency"},
+		},
+		{
+			name:    "empty intent succeeds",
+			handler: reference{emptySuccess: true},
+			failing: []string{"empty-intent"},
+		},
+		{
+			name:    "GET allowed",
+			handler: reference{allowGet: true},
+			failing: []string{"post-only"},
+		},
+		{
+			name:    "plain text answer",
+			handler: reference{plainText: true},
+			failing: []string{"response-shape", "success-semantics", "unknown-fields", "concurrency"},
+		},
+		{
+			name:    "unknown fields rejected",
+			handler: reference{strictFields: true},
+			failing: []string{"unknown-fields"},
+		},
+		{
+			name:    "large body crashes",
+			handler: reference{panicOnLarge: true},
+			failing: []string{"large-body"},
+		},
+		{
+			name:    "drifted service name",
+			handler: reference{driftedName: true},
+			failing: []string{"response-shape", "success-semantics", "unknown-fields", "concurrency"},
+		},
+		{
+			name:    "success without data",
+			handler: reference{successNoData: true},
+			failing: []string{"response-shape", "success-semantics", "unknown-fields", "concurrency"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.handler)
+			defer srv.Close()
+
+			results := Verify(context.Background(), srv.URL, Config{Concurrency: 5})
+			if len(results) != len(Checks) {
+				t.Fatalf("expected %d results, got %d", len(Checks), len(results))
+			}
+
+			var failing []string
+			for _, r := range results {
+				if !r.Passed {
+					failing = append(failing, r.Check)
+				}
+			}
+
+			if strings.Join(failing, ",") != strings.Join(tt.failing, ",") {
+				t.Errorf("expected failing checks %v, got %v (%+v)", tt.failing, failing, results)
+			}
+			if Passed(results) != (len(tt.failing) == 0) {
+				t.Errorf("expected Passed %v", len(tt.failing) == 0)
+			}
+		})
+	}
+}
+
+func TestVerifyUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	results := Verify(context.Background(), srv.URL, Config{})
+	if Passed(results) {
+		t.Error("expected checks to fail against a closed server")
+	}
+	for _, r := range results {
+		if r.Message == "" {
+			t.Errorf("%s: expected a failure message", r.Check)
+		}
+	}
+}
+
+func TestVerifyNoIntents(t *testing.T) {
+	srv := httptest.NewServer(reference{})
+	defer srv.Close()
+
+	results := Verify(context.Background(), srv.URL, Config{Catalog: &catalog.Catalog{}})
+
+	failing := []string{"response-shape", "success-semantics", "unknown-fields", "concurrency"}
+	for _, r := range results {
+		wantFailed := slices.Contains(failing, r.Check)
+		if r.Passed == wantFailed {
+			t.Errorf("%s: expected passed %v, got %v: %s", r.Check, !wantFailed, r.Passed, r.Message)
+		}
+		if wantFailed && r.Message != ErrNoIntents.Error() {
+			t.Errorf("%s: expected message %q, got %q", r.Check, ErrNoIntents, r.Message)
+		}
+	}
+}
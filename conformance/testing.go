@@ -0,0 +1,45 @@
+package conformance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Run runs every check as a subtest of t against the implementation
+// listening at baseURL.
+func Run(t *testing.T, baseURL string, cfg Config) {
+	t.Helper()
+
+	s := &Session{BaseURL: strings.TrimSuffix(baseURL, "/"), Config: cfg.withDefaults()}
+
+	for _, check := range Checks {
+		t.Run(check.Name, func(t *testing.T) {
+			if err := check.Run(context.Background(), s); err != nil {
+				t.Errorf("%s: %v", check.Description, err)
+			}
+		})
+	}
+}
+
+// RunHandler serves h on a test server and runs every check against it, so
+// an implementation can assert the contract from its own tests:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.RunHandler(t, handler.New(classifier), conformance.Config{})
+//	}
+func RunHandler(t *testing.T, h http.Handler, cfg Config) {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	if cfg.Client == nil {
+		cfg.Client = srv.Client()
+		cfg.Client.Timeout = DefaultTimeout
+	}
+
+	Run(t, srv.URL, cfg)
+}
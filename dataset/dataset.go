@@ -0,0 +1,197 @@
+// Package dataset reads, checks and reshapes intent CSVs in the
+// "service_id;service_name;intent" format of assets/intents_pre_loaded.csv.
+package dataset
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"credsystem-hackathon/catalog"
+)
+
+// Severity tells whether an issue makes a file unusable.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+var bom = []byte("\xef\xbb\xbf")
+
+// Issue is a problem found by Validate on a line of the file.
+type Issue struct {
+	Line     int      `json:"line"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("line %d: %s: %s", i.Line, i.Severity, i.Message)
+}
+
+// HasErrors reports whether any issue is an error.
+func HasErrors(issues []Issue) bool {
+	for _, i := range issues {
+		if i.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReadFile reads the intents of a CSV file.
+func ReadFile(path string) ([]catalog.Intent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	intents, err := catalog.ReadIntents(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return intents, nil
+}
+
+// Write writes intents as CSV, header first.
+func Write(w io.Writer, intents []catalog.Intent) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = ';'
+
+	if err := writer.Write(catalog.Header); err != nil {
+		return err
+	}
+
+	for _, intent := range intents {
+		if err := writer.Write([]string{strconv.Itoa(int(intent.ID)), intent.Name, intent.Text}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// WriteFile writes intents to a CSV file.
+func WriteFile(path string, intents []catalog.Intent) error {
+	var buf bytes.Buffer
+	if err := Write(&buf, intents); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// Validate checks a CSV file against c: UTF-8 encoding, the ';' delimiter,
+// the header, and that every line has a known service_id carrying its
+// catalog name and a non-empty intent.
+func Validate(data []byte, c *catalog.Catalog) []Issue {
+	var issues []Issue
+
+	if bytes.HasPrefix(data, bom) {
+		issues = append(issues, Issue{Line: 1, Severity: SeverityWarning, Message: "file starts with a UTF-8 byte order mark"})
+		data = data[len(bom):]
+	}
+
+	if !utf8.Valid(data) {
+		line := 1 + bytes.Count(data[:invalidUTF8(data)], []byte("\n"))
+		return append(issues, Issue{Line: line, Severity: SeverityError, Message: "file is not valid UTF-8, was it saved as Latin-1?"})
+	}
+
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	header = bytes.TrimSuffix(header, []byte("\r"))
+	if want := strings.Join(catalog.Header, ";"); string(header) != want {
+		message := fmt.Sprintf("invalid header %q, expected %q", header, want)
+		for _, delimiter := range []string{",", "\t", "|"} {
+			if strings.Join(catalog.Header, delimiter) == string(header) {
+				message = fmt.Sprintf("delimiter is %q, expected ';'", delimiter)
+			}
+		}
+
+		return append(issues, Issue{Line: 1, Severity: SeverityError, Message: message})
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	reader.Read() // header, checked above
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			// quoting errors leave the rest of the file unreadable
+			issues = append(issues, Issue{Line: line, Severity: SeverityError, Message: err.Error()})
+			break
+		}
+
+		issues = append(issues, validateRecord(line, record, c)...)
+	}
+
+	return issues
+}
+
+func validateRecord(line int, record []string, c *catalog.Catalog) []Issue {
+	issue := func(severity Severity, format string, args ...any) Issue {
+		return Issue{Line: line, Severity: severity, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if len(record) != len(catalog.Header) {
+		return []Issue{issue(SeverityError, "expected %d fields, got %d", len(catalog.Header), len(record))}
+	}
+
+	var issues []Issue
+
+	id, err := strconv.ParseUint(record[0], 10, 8)
+	service, known := c.ByID(uint8(id))
+	switch {
+	case err != nil:
+		issues = append(issues, issue(SeverityError, "invalid service_id %q", record[0]))
+	case !known:
+		issues = append(issues, issue(SeverityError, "unknown service_id %d", id))
+	case record[1] != service.Name:
+		if canonical, ok := c.Canonicalize(record[1]); ok && canonical.ID != service.ID {
+			issues = append(issues, issue(SeverityError, "service_name %q belongs to service %d, not %d", record[1], canonical.ID, id))
+		} else {
+			issues = append(issues, issue(SeverityError, "service_name %q does not match the catalog name %q", record[1], service.Name))
+		}
+	}
+
+	switch intent := record[2]; {
+	case strings.TrimSpace(intent) == "":
+		issues = append(issues, issue(SeverityError, "empty intent"))
+	case strings.TrimSpace(intent) != intent:
+		issues = append(issues, issue(SeverityWarning, "intent %q has surrounding whitespace", intent))
+	}
+
+	return issues
+}
+
+// invalidUTF8 returns the offset of the first invalid UTF-8 sequence.
+func invalidUTF8(data []byte) int {
+	for i := 0; i < len(data); {
+		r, size := utf8.DecodeRune(data[i:])
+		if r == utf8.RuneError && size <= 1 {
+			return i
+		}
+		i += size
+	}
+
+	return len(data)
+}
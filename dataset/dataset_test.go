@@ -0,0 +1,118 @@
+package dataset
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"credsystem-hackathon/assets"
+	"credsystem-hackathon/catalog"
+)
+
+const header = "service_id;service_name;intent\n"
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid",
+			input: header + "12;Consulta do Saldo;qual meu saldo\n",
+		},
+		{
+			name:  "byte order mark",
+			input: "\xef\xbb\xbf" + header + "12;Consulta do Saldo;qual meu saldo\n",
+			want:  []string{"line 1: warning: file starts with a UTF-8 byte order mark"},
+		},
+		{
+			name:  "latin-1",
+			input: header + "12;Consulta do Saldo;qual meu saldo\n7;Cancelamento de cart\xe3o;cancelar\n",
+			want:  []string{"line 3: error: file is not valid UTF-8, was it saved as Latin-1?"},
+		},
+		{
+			name:  "comma delimiter",
+			input: "service_id,service_name,intent\n12,Consulta do Saldo,qual meu saldo\n",
+			want:  []string{`line 1: error: delimiter is ",", expected ';'`},
+		},
+		{
+			name:  "wrong field count",
+			input: header + "12;qual meu saldo\n12;Consulta do Saldo;saldo\n",
+			want:  []string{"line 2: error: expected 3 fields, got 2"},
+		},
+		{
+			name:  "unknown id",
+			input: header + "17;Cartoes de Credito;fatura do cartão\n",
+			want:  []string{"line 2: error: unknown service_id 17"},
+		},
+		{
+			name:  "drifted name",
+			input: header + "12;Consulta do Saldo Conta do Mais;qual meu saldo\n",
+			want:  []string{`line 2: error: service_name "Consulta do Saldo Conta do Mais" does not match the catalog name "Consulta do Saldo"`},
+		},
+		{
+			name:  "name of another service",
+			input: header + "12;Cancelamento de cartão;qual meu saldo\n",
+			want:  []string{`line 2: error: service_name "Cancelamento de cartão" belongs to service 7, not 12`},
+		},
+		{
+			name:  "intent whitespace",
+			input: header + "12;Consulta do Saldo; qual meu saldo\n12;Consulta do Saldo;\n",
+			want: []string{
+				`line 2: warning: intent " qual meu saldo" has surrounding whitespace`,
+				"line 3: error: empty intent",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range Validate([]byte(tt.input), catalog.Default) {
+				got = append(got, issue.String())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateAssets(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"intents_pre_loaded.csv": assets.IntentsPreLoaded,
+		"extra_intents.csv":      assets.ExtraIntents,
+	} {
+		if issues := Validate(data, catalog.Default); len(issues) > 0 {
+			t.Errorf("%s: unexpected issues: %v", name, issues)
+		}
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	want := []catalog.Intent{
+		{Service: catalog.Service{ID: 12, Name: "Consulta do Saldo"}, Text: "qual meu saldo"},
+		{Service: catalog.Service{ID: 14, Name: "Reclamações"}, Text: `quero reclamar; "urgente"`},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, want); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), header) {
+		t.Errorf("expected header %q, got %q", header, buf.String())
+	}
+
+	got, err := catalog.ReadIntents(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
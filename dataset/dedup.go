@@ -0,0 +1,147 @@
+package dataset
+
+import (
+	"credsystem-hackathon/catalog"
+)
+
+// DefaultNearThreshold is the similarity above which two intents are near
+// duplicates, e.g. "quero cancelar meu cartão" and "quero cancelar o meu
+// cartão".
+const DefaultNearThreshold = 0.8
+
+type (
+	// Duplicate pairs an intent with an earlier one it repeats.
+	Duplicate struct {
+		Intent   catalog.Intent `json:"intent"`
+		Original catalog.Intent `json:"original"`
+		// Similarity is 1 for exact duplicates, which only differ in case,
+		// accents, punctuation or spacing.
+		Similarity float64 `json:"similarity"`
+		// Conflict is set when both carry different services: one of the
+		// labels is wrong, so neither is dropped.
+		Conflict bool `json:"conflict"`
+	}
+
+	// Leak is an evaluation intent found in the training data.
+	Leak struct {
+		Eval       catalog.Intent `json:"eval"`
+		Train      catalog.Intent `json:"train"`
+		Similarity float64        `json:"similarity"`
+	}
+)
+
+// Similarity compares two intents from 0 to 1: the Jaccard index of the
+// character trigrams of their normalized text.
+func Similarity(a, b string) float64 {
+	return jaccard(trigrams(catalog.Normalize(a)), trigrams(catalog.Normalize(b)))
+}
+
+// Dedup drops the intents repeating an earlier one, exactly or with a
+// similarity of at least threshold, and returns what it dropped. Repeats
+// with another service are reported as conflicts and kept. A threshold of 1
+// or more only drops exact duplicates.
+func Dedup(intents []catalog.Intent, threshold float64) ([]catalog.Intent, []Duplicate) {
+	var (
+		kept       []catalog.Intent
+		keptGrams  []map[string]bool
+		duplicates []Duplicate
+		exact      = make(map[string]int)
+	)
+
+	for _, intent := range intents {
+		key := catalog.Normalize(intent.Text)
+
+		dup, found := Duplicate{Intent: intent}, false
+		if i, ok := exact[key]; ok {
+			dup.Original, dup.Similarity, found = kept[i], 1, true
+		} else if threshold < 1 {
+			grams := trigrams(key)
+			for i, other := range keptGrams {
+				if s := jaccard(grams, other); s >= threshold && s > dup.Similarity {
+					dup.Original, dup.Similarity, found = kept[i], s, true
+				}
+			}
+		}
+
+		if found {
+			dup.Conflict = dup.Original.ID != intent.ID
+			duplicates = append(duplicates, dup)
+			if !dup.Conflict {
+				continue
+			}
+		}
+
+		if _, ok := exact[key]; !ok {
+			exact[key] = len(kept)
+		}
+		kept = append(kept, intent)
+		keptGrams = append(keptGrams, trigrams(key))
+	}
+
+	return kept, duplicates
+}
+
+// Leakage finds the eval intents that also appear in train, exactly or with
+// a similarity of at least threshold. Each eval intent is reported once,
+// with its closest training intent.
+func Leakage(train, eval []catalog.Intent, threshold float64) []Leak {
+	trainGrams := make([]map[string]bool, len(train))
+	exact := make(map[string]int, len(train))
+	for i, intent := range train {
+		key := catalog.Normalize(intent.Text)
+		trainGrams[i] = trigrams(key)
+		if _, ok := exact[key]; !ok {
+			exact[key] = i
+		}
+	}
+
+	var leaks []Leak
+	for _, intent := range eval {
+		key := catalog.Normalize(intent.Text)
+		if i, ok := exact[key]; ok {
+			leaks = append(leaks, Leak{Eval: intent, Train: train[i], Similarity: 1})
+			continue
+		}
+
+		leak := Leak{Eval: intent}
+		grams := trigrams(key)
+		for i, other := range trainGrams {
+			if s := jaccard(grams, other); s >= threshold && s > leak.Similarity {
+				leak.Train, leak.Similarity = train[i], s
+			}
+		}
+		if leak.Similarity > 0 {
+			leaks = append(leaks, leak)
+		}
+	}
+
+	return leaks
+}
+
+// trigrams returns the character trigrams of s, padded so that short words
+// still have some.
+func trigrams(s string) map[string]bool {
+	runes := []rune(" " + s + " ")
+
+	grams := make(map[string]bool, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		grams[string(runes[i:i+3])] = true
+	}
+
+	return grams
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+
+	var shared int
+	for gram := range a {
+		if b[gram] {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,96 @@
+package dataset
+
+import (
+	"testing"
+
+	"credsystem-hackathon/catalog"
+)
+
+func intent(id uint8, text string) catalog.Intent {
+	service, _ := catalog.Default.ByID(id)
+	return catalog.Intent{Service: service, Text: text}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		min  float64
+		max  float64
+	}{
+		{a: "Quero cancelar o cartão!", b: "quero cancelar o cartao", min: 1, max: 1},
+		{a: "quero cancelar meu cartão", b: "quero cancelar o meu cartão", min: 0.8, max: 1},
+		{a: "qual meu saldo", b: "cancelar cartão", min: 0, max: 0.2},
+	}
+
+	for _, tt := range tests {
+		if got := Similarity(tt.a, tt.b); got < tt.min || got > tt.max {
+			t.Errorf("%q ~ %q: expected similarity in [%v, %v], got %v", tt.a, tt.b, tt.min, tt.max, got)
+		}
+	}
+}
+
+func TestDedup(t *testing.T) {
+	intents := []catalog.Intent{
+		intent(7, "quero cancelar meu cartão"),
+		intent(7, "Quero cancelar meu cartao!"),
+		intent(7, "quero cancelar o meu cartão"),
+		intent(11, "quero cancelar meu cartão"),
+		intent(12, "qual meu saldo"),
+	}
+
+	tests := []struct {
+		name      string
+		threshold float64
+		wantKept  int
+		wantDups  int
+	}{
+		{name: "exact only", threshold: 1, wantKept: 4, wantDups: 2},
+		{name: "near", threshold: DefaultNearThreshold, wantKept: 3, wantDups: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, dups := Dedup(intents, tt.threshold)
+
+			if len(kept) != tt.wantKept {
+				t.Errorf("expected %d kept, got %d: %v", tt.wantKept, len(kept), kept)
+			}
+			if len(dups) != tt.wantDups {
+				t.Fatalf("expected %d duplicates, got %d: %v", tt.wantDups, len(dups), dups)
+			}
+
+			conflict := dups[len(dups)-1]
+			if !conflict.Conflict || conflict.Intent.ID != 11 || conflict.Original.ID != 7 {
+				t.Errorf("expected a 11 vs 7 conflict, got %+v", conflict)
+			}
+		})
+	}
+}
+
+func TestLeakage(t *testing.T) {
+	train := []catalog.Intent{
+		intent(7, "bloquear cartão definitivamente"),
+		intent(12, "qual meu saldo"),
+	}
+	eval := []catalog.Intent{
+		intent(12, "Qual meu saldo?"),
+		intent(7, "Me diz bloquear cartão definitivamente"),
+		intent(10, "esqueci minha senha"),
+	}
+
+	leaks := Leakage(train, eval, DefaultNearThreshold)
+	if len(leaks) != 2 {
+		t.Fatalf("expected 2 leaks, got %d: %v", len(leaks), leaks)
+	}
+
+	if leaks[0].Similarity != 1 || leaks[0].Train.Text != "qual meu saldo" {
+		t.Errorf("expected an exact leak of qual meu saldo, got %+v", leaks[0])
+	}
+	if leaks[1].Similarity >= 1 || leaks[1].Train.Text != "bloquear cartão definitivamente" {
+		t.Errorf("expected a near leak of bloquear cartão definitivamente, got %+v", leaks[1])
+	}
+
+	if leaks := Leakage(train, eval, 1); len(leaks) != 1 {
+		t.Errorf("expected 1 exact leak, got %d", len(leaks))
+	}
+}
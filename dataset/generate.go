@@ -0,0 +1,324 @@
+package dataset
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"credsystem-hackathon/catalog"
+)
+
+const (
+	DefaultVariantsPerSeed = 5
+	DefaultTypoRate        = 0.2
+)
+
+// Register is the tone of a generated intent.
+type Register string
+
+const (
+	RegisterNeutral  Register = "neutral"
+	RegisterPolite   Register = "polite"
+	RegisterInformal Register = "informal"
+)
+
+// DefaultSynonyms lists, per service, groups of interchangeable words. A
+// synonym only replaces another member of its group within the same
+// service, so the label of the seed still holds.
+var DefaultSynonyms = map[uint8][][]string{
+	1:  {{"vence", "vai vencer"}, {"fecha", "vira"}, {"disponível", "livre"}, {"gastar", "usar"}, {"comprar", "fazer compras"}},
+	2:  {{"acordo", "negociação", "renegociação"}, {"segunda via", "2ª via"}, {"enviar", "mandar"}},
+	3:  {{"segunda via", "2ª via", "cópia"}, {"enviar", "mandar"}, {"código de barras", "linha digitável"}},
+	4:  {{"chegou", "foi entregue"}, {"entrega", "envio"}, {"enviado", "despachado"}, {"onde está", "cadê"}},
+	5:  {{"não funciona", "não está funcionando"}, {"recusado", "negado"}, {"problema", "defeito"}},
+	6:  {{"aumentar", "subir"}, {"mais limite", "limite maior"}, {"aumento", "elevação"}},
+	7:  {{"cancelar", "encerrar"}, {"cancelamento", "encerramento"}, {"desistir", "abrir mão"}},
+	8:  {{"seguro", "seguradora"}, {"telefone", "número", "contato"}, {"falar com", "ligar para"}},
+	9:  {{"desbloquear", "liberar", "ativar"}, {"desbloqueio", "liberação"}},
+	10: {{"esqueci", "não lembro"}, {"trocar", "mudar", "alterar"}, {"recuperar", "redefinir"}, {"nova", "outra"}},
+	11: {{"perdi", "extraviei"}, {"roubaram", "levaram"}, {"furtado", "roubado"}, {"perda", "extravio"}},
+	12: {{"consultar", "ver", "verificar"}, {"conta corrente", "conta"}, {"atual", "de hoje"}, {"extrato", "movimentação"}},
+	13: {{"pagar", "quitar"}, {"efetuar", "fazer"}},
+	14: {{"reclamar", "fazer uma reclamação"}, {"queixa", "denúncia"}, {"registrar", "abrir"}},
+	15: {{"atendente", "operador", "pessoa"}, {"falar com", "conversar com"}},
+	16: {{"receber", "obter"}, {"número", "código"}},
+}
+
+var (
+	// actionTemplates wrap seeds starting with an infinitive, like
+	// "cancelar cartão".
+	actionTemplates = []string{
+		"quero %s", "preciso %s", "como faço para %s", "gostaria de %s",
+		"queria %s", "me ajuda a %s", "tem como %s?",
+	}
+
+	// questionTemplates wrap seeds that already ask something, like
+	// "quando vence meu cartão".
+	questionTemplates = []string{
+		"me diz %s", "gostaria de saber %s", "queria saber %s", "você sabe %s?",
+	}
+
+	// requestTemplates wrap seeds that already ask for something, like
+	// "quero mais limite".
+	requestTemplates = []string{
+		"%s", "eu %s", "%s, pode me ajudar?",
+	}
+
+	// statementTemplates wrap everything else, like "perdi meu cartão" or
+	// "segunda via de fatura".
+	statementTemplates = []string{
+		"%s", "%s, o que eu faço?", "preciso de ajuda, %s", "%s, pode me ajudar?",
+	}
+
+	registerTemplates = map[Register][]string{
+		RegisterNeutral:  {"%s"},
+		RegisterPolite:   {"por favor, %s", "%s, por favor", "bom dia, %s", "olá, %s, por gentileza"},
+		RegisterInformal: {"oi, %s", "%s aí", "e aí, %s"},
+	}
+
+	informalWords = [][2]string{
+		{"você", "vc"}, {"para", "pra"}, {"está", "tá"}, {"não", "nao"}, {"por favor", "pfv"},
+	}
+
+	interrogatives = []string{
+		"quando", "qual", "quais", "quanto", "quantos", "onde", "como", "cadê", "o que", "por que",
+	}
+
+	requests = []string{"quero", "preciso", "gostaria", "queria"}
+)
+
+// GenerateOptions configures Generate. Zero values fall back to the
+// defaults, except TypoRate.
+type GenerateOptions struct {
+	// VariantsPerSeed is how many variants are kept for every seed intent.
+	VariantsPerSeed int
+	// TypoRate is the fraction of variants given a single typo, none when
+	// zero; DefaultTypoRate is a sensible value.
+	TypoRate float64
+	// Registers restricts the tones generated, all of them by default.
+	Registers []Register
+	// Synonyms replaces DefaultSynonyms.
+	Synonyms map[uint8][][]string
+	// Seed makes the output reproducible.
+	Seed uint64
+}
+
+// Generate paraphrases every seed offline: synonym substitution, Portuguese
+// prefixes like "quero" or "como faço para", polite and informal registers
+// and controlled typos. Variants keep the label of their seed and never
+// repeat a seed or each other, ignoring case and accents.
+func Generate(seeds []catalog.Intent, opts GenerateOptions) []catalog.Intent {
+	if opts.VariantsPerSeed <= 0 {
+		opts.VariantsPerSeed = DefaultVariantsPerSeed
+	}
+	if opts.Synonyms == nil {
+		opts.Synonyms = DefaultSynonyms
+	}
+	if len(opts.Registers) == 0 {
+		opts.Registers = []Register{RegisterNeutral, RegisterPolite, RegisterInformal}
+	}
+
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed))
+
+	seen := make(map[string]bool)
+	for _, seed := range seeds {
+		seen[catalog.Normalize(seed.Text)] = true
+	}
+
+	var variants []catalog.Intent
+	for _, seed := range seeds {
+		candidates := paraphrases(seed, opts)
+		rng.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+
+		kept := 0
+		for _, text := range candidates {
+			if kept == opts.VariantsPerSeed {
+				break
+			}
+
+			if rng.Float64() < opts.TypoRate {
+				text = typo(text, rng)
+			}
+
+			key := catalog.Normalize(text)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			variants = append(variants, catalog.Intent{Service: seed.Service, Text: text})
+			kept++
+		}
+	}
+
+	return variants
+}
+
+// paraphrases returns every template and register applied to the seed and
+// its synonym variants, sorted so that the shuffle alone decides the order.
+func paraphrases(seed catalog.Intent, opts GenerateOptions) []string {
+	base := strings.TrimRight(strings.ToLower(strings.TrimSpace(seed.Text)), "?!. ")
+
+	bases := []string{base}
+	for _, group := range opts.Synonyms[seed.ID] {
+		for _, word := range group {
+			if !containsWord(base, word) {
+				continue
+			}
+			for _, synonym := range group {
+				if synonym != word {
+					bases = append(bases, replaceWord(base, word, synonym))
+				}
+			}
+		}
+	}
+
+	set := make(map[string]bool)
+	for _, b := range bases {
+		for _, template := range templatesFor(b) {
+			phrase := strings.Replace(template, "%s", b, 1)
+			for _, register := range opts.Registers {
+				for _, wrapper := range registerTemplates[register] {
+					text := wrap(wrapper, phrase)
+					if register == RegisterInformal {
+						text = informal(text)
+					}
+					set[text] = true
+				}
+			}
+		}
+	}
+
+	candidates := make([]string, 0, len(set))
+	for text := range set {
+		candidates = append(candidates, text)
+	}
+	sort.Strings(candidates)
+
+	return candidates
+}
+
+// wrap fills template with phrase, moving a question mark ending phrase to
+// the end of the sentence.
+func wrap(template, phrase string) string {
+	question, ok := strings.CutSuffix(phrase, "?")
+	if !ok || strings.HasSuffix(template, "%s") {
+		return strings.Replace(template, "%s", phrase, 1)
+	}
+
+	return strings.TrimSuffix(strings.Replace(template, "%s", question, 1), "?") + "?"
+}
+
+// informal swaps words for their chat spelling, like "você" for "vc".
+func informal(text string) string {
+	for _, pair := range informalWords {
+		for containsWord(text, pair[0]) {
+			text = replaceWord(text, pair[0], pair[1])
+		}
+	}
+
+	return text
+}
+
+func templatesFor(base string) []string {
+	for _, word := range interrogatives {
+		if strings.HasPrefix(base, word+" ") {
+			return questionTemplates
+		}
+	}
+
+	first, _, _ := strings.Cut(base, " ")
+	if slices.Contains(requests, first) {
+		return requestTemplates
+	}
+
+	if utf8.RuneCountInString(first) > 3 &&
+		(strings.HasSuffix(first, "ar") || strings.HasSuffix(first, "er") || strings.HasSuffix(first, "ir")) {
+		return actionTemplates
+	}
+
+	return statementTemplates
+}
+
+// typo misspells one word of at least four letters the way people do on a
+// phone: two letters swapped, one dropped, one doubled or the accents left
+// out. Text without such a word is returned as it is.
+func typo(text string, rng *rand.Rand) string {
+	words := strings.Fields(text)
+
+	var candidates []int
+	for i, word := range words {
+		if utf8.RuneCountInString(word) >= 4 && isWord(word) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return text
+	}
+
+	i := candidates[rng.IntN(len(candidates))]
+	runes := []rune(words[i])
+	pos := 1 + rng.IntN(len(runes)-2)
+
+	switch rng.IntN(4) {
+	case 0:
+		runes[pos], runes[pos+1] = runes[pos+1], runes[pos]
+	case 1:
+		runes = slices.Delete(runes, pos, pos+1)
+	case 2:
+		runes = slices.Insert(runes, pos, runes[pos])
+	default:
+		runes = []rune(catalog.FoldAccents(string(runes)))
+	}
+	words[i] = string(runes)
+
+	return strings.Join(words, " ")
+}
+
+func isWord(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsWord reports whether phrase appears in s as whole words.
+func containsWord(s, phrase string) bool {
+	return wordIndex(s, phrase) >= 0
+}
+
+// replaceWord replaces the first whole-word occurrence of phrase in s.
+func replaceWord(s, phrase, replacement string) string {
+	i := wordIndex(s, phrase)
+	if i < 0 {
+		return s
+	}
+
+	return s[:i] + replacement + s[i+len(phrase):]
+}
+
+func wordIndex(s, phrase string) int {
+	for offset := 0; offset <= len(s); {
+		i := strings.Index(s[offset:], phrase)
+		if i < 0 {
+			return -1
+		}
+		i += offset
+
+		before, _ := utf8.DecodeLastRuneInString(s[:i])
+		after, _ := utf8.DecodeRuneInString(s[i+len(phrase):])
+		if (i == 0 || !unicode.IsLetter(before)) && (i+len(phrase) == len(s) || !unicode.IsLetter(after)) {
+			return i
+		}
+
+		offset = i + 1
+	}
+
+	return -1
+}
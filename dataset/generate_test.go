@@ -0,0 +1,144 @@
+package dataset
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"credsystem-hackathon/catalog"
+)
+
+func TestGenerate(t *testing.T) {
+	seeds := catalog.Default.Intents()
+
+	variants := Generate(seeds, GenerateOptions{Seed: 1})
+	if len(variants) != len(seeds)*DefaultVariantsPerSeed {
+		t.Errorf("expected %d variants, got %d", len(seeds)*DefaultVariantsPerSeed, len(variants))
+	}
+
+	seen := make(map[string]bool)
+	for _, seed := range seeds {
+		seen[catalog.Normalize(seed.Text)] = true
+	}
+
+	for _, v := range variants {
+		if err := catalog.Default.Check(v.ID, v.Name); err != nil {
+			t.Errorf("%q: %v", v.Text, err)
+		}
+
+		key := catalog.Normalize(v.Text)
+		if seen[key] {
+			t.Errorf("%q repeats a seed or another variant", v.Text)
+		}
+		seen[key] = true
+	}
+
+	if issues := Validate(encode(t, variants), catalog.Default); len(issues) > 0 {
+		t.Errorf("unexpected issues: %v", issues)
+	}
+
+	if again := Generate(seeds, GenerateOptions{Seed: 1}); !reflect.DeepEqual(again, variants) {
+		t.Error("expected the same seed to give the same variants")
+	}
+}
+
+func TestGenerateOptions(t *testing.T) {
+	seeds := []catalog.Intent{intent(7, "cancelar cartão")}
+
+	variants := Generate(seeds, GenerateOptions{
+		VariantsPerSeed: 100,
+		Registers:       []Register{RegisterNeutral},
+		Seed:            1,
+	})
+
+	var synonyms int
+	for _, v := range variants {
+		if v.ID != 7 {
+			t.Errorf("%q: expected service 7, got %d", v.Text, v.ID)
+		}
+		if strings.Contains(v.Text, "por favor") || strings.HasPrefix(v.Text, "oi,") {
+			t.Errorf("%q: expected the neutral register only", v.Text)
+		}
+		if strings.Contains(v.Text, "encerrar") {
+			synonyms++
+		}
+	}
+
+	// 7 action templates for "cancelar cartão" and "encerrar cartão"
+	if len(variants) != 14 {
+		t.Errorf("expected 14 variants, got %d: %v", len(variants), variants)
+	}
+	if synonyms != 7 {
+		t.Errorf("expected 7 variants with the synonym, got %d", synonyms)
+	}
+}
+
+func TestGenerateTypos(t *testing.T) {
+	seeds := catalog.Default.Intents()
+
+	clean := Generate(seeds, GenerateOptions{Seed: 1})
+	typos := Generate(seeds, GenerateOptions{Seed: 1, TypoRate: 1})
+
+	changed := 0
+	for i := range typos {
+		if typos[i].Text != clean[i].Text {
+			changed++
+		}
+	}
+
+	if changed < len(typos)/2 {
+		t.Errorf("expected most variants to carry a typo, got %d of %d", changed, len(typos))
+	}
+}
+
+func TestTemplatesFor(t *testing.T) {
+	tests := []struct {
+		base string
+		want []string
+	}{
+		{base: "cancelar cartão", want: actionTemplates},
+		{base: "quando vence meu cartão", want: questionTemplates},
+		{base: "quero mais limite", want: requestTemplates},
+		{base: "perdi meu cartão", want: statementTemplates},
+		{base: "ver", want: statementTemplates},
+	}
+
+	for _, tt := range tests {
+		if got := templatesFor(tt.base); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: expected %v, got %v", tt.base, tt.want, got)
+		}
+	}
+}
+
+func TestWordReplacement(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+		fn             func(string) string
+	}{
+		{name: "informal", in: "você sabe para onde está", want: "vc sabe pra onde tá", fn: informal},
+		{name: "informal whole words", in: "separar parabéns", want: "separar parabéns", fn: informal},
+		{name: "question mark", in: "tem como pagar?", want: "tem como pagar, por favor?", fn: func(s string) string { return wrap("%s, por favor", s) }},
+		{name: "question mark at the end", in: "tem como pagar?", want: "por favor, tem como pagar?", fn: func(s string) string { return wrap("por favor, %s", s) }},
+		{name: "replace", in: "cancelamento de crédito", want: "encerramento de crédito", fn: func(s string) string { return replaceWord(s, "cancelamento", "encerramento") }},
+		{name: "replace within word", in: "cancelamento", want: "cancelamento", fn: func(s string) string { return replaceWord(s, "cancela", "encerra") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(tt.in); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func encode(t *testing.T, intents []catalog.Intent) []byte {
+	t.Helper()
+
+	var buf strings.Builder
+	if err := Write(&buf, intents); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return []byte(buf.String())
+}
@@ -0,0 +1,90 @@
+package dataset
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"credsystem-hackathon/catalog"
+)
+
+// ClassCount is the number of intents of one service.
+type ClassCount struct {
+	catalog.Service
+	Count int     `json:"count"`
+	Share float64 `json:"share"`
+}
+
+// Balance counts the intents of every service of c, including the services
+// without any, ordered by ID.
+func Balance(intents []catalog.Intent, c *catalog.Catalog) []ClassCount {
+	counts := make(map[uint8]int)
+	for _, intent := range intents {
+		counts[intent.ID]++
+	}
+
+	var balance []ClassCount
+	for _, service := range c.Services() {
+		count := ClassCount{Service: service, Count: counts[service.ID]}
+		if len(intents) > 0 {
+			count.Share = float64(count.Count) / float64(len(intents))
+		}
+		balance = append(balance, count)
+	}
+
+	return balance
+}
+
+// Merge concatenates sets, replacing drifted service names by their catalog
+// name and dropping exact duplicates. Intents whose service is unknown to c
+// are returned apart.
+func Merge(c *catalog.Catalog, sets ...[]catalog.Intent) (merged, rejected []catalog.Intent) {
+	var all []catalog.Intent
+	for _, set := range sets {
+		for _, intent := range set {
+			service, ok := c.ByID(intent.ID)
+			if !ok {
+				rejected = append(rejected, intent)
+				continue
+			}
+
+			intent.Service = service
+			all = append(all, intent)
+		}
+	}
+
+	merged, _ = Dedup(all, 1)
+
+	return merged, rejected
+}
+
+// Split divides intents into a training and a test set, taking testFraction
+// of every service into the test set so both keep the class balance. With a
+// fraction strictly between 0 and 1, a service with at least two intents
+// always has one on each side. The same seed gives the same split.
+func Split(intents []catalog.Intent, testFraction float64, seed uint64) (train, test []catalog.Intent) {
+	byService := make(map[uint8][]catalog.Intent)
+	var order []uint8
+	for _, intent := range intents {
+		if _, ok := byService[intent.ID]; !ok {
+			order = append(order, intent.ID)
+		}
+		byService[intent.ID] = append(byService[intent.ID], intent)
+	}
+
+	rng := rand.New(rand.NewPCG(seed, seed))
+
+	for _, id := range order {
+		group := byService[id]
+		rng.Shuffle(len(group), func(i, j int) { group[i], group[j] = group[j], group[i] })
+
+		n := int(math.Round(float64(len(group)) * testFraction))
+		if len(group) >= 2 && testFraction > 0 && testFraction < 1 {
+			n = max(1, min(n, len(group)-1))
+		}
+
+		test = append(test, group[:n]...)
+		train = append(train, group[n:]...)
+	}
+
+	return train, test
+}
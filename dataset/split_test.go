@@ -0,0 +1,81 @@
+package dataset
+
+import (
+	"reflect"
+	"testing"
+
+	"credsystem-hackathon/catalog"
+)
+
+func TestBalance(t *testing.T) {
+	balance := Balance([]catalog.Intent{
+		intent(12, "qual meu saldo"),
+		intent(12, "saldo"),
+		intent(7, "cancelar cartão"),
+		intent(7, "cancelar"),
+	}, catalog.Default)
+
+	if len(balance) != 16 {
+		t.Fatalf("expected 16 classes, got %d", len(balance))
+	}
+
+	if balance[6].ID != 7 || balance[6].Count != 2 || balance[6].Share != 0.5 {
+		t.Errorf("expected service 7 with 2 intents and 0.5 share, got %+v", balance[6])
+	}
+	if balance[0].Count != 0 {
+		t.Errorf("expected no intents for service 1, got %d", balance[0].Count)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	drifted := intent(12, "saldo da conta")
+	drifted.Name = "Consulta do Saldo Conta do Mais"
+
+	unknown := catalog.Intent{Service: catalog.Service{ID: 17, Name: "Cartoes de Credito"}, Text: "cartão de crédito"}
+
+	merged, rejected := Merge(catalog.Default,
+		[]catalog.Intent{intent(12, "qual meu saldo"), drifted},
+		[]catalog.Intent{intent(12, "Qual meu saldo?"), unknown},
+	)
+
+	want := []catalog.Intent{intent(12, "qual meu saldo"), intent(12, "saldo da conta")}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("expected %v, got %v", want, merged)
+	}
+	if !reflect.DeepEqual(rejected, []catalog.Intent{unknown}) {
+		t.Errorf("expected %v rejected, got %v", unknown, rejected)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	intents := catalog.Default.Intents()
+
+	train, test := Split(intents, 0.2, 1)
+	if len(train)+len(test) != len(intents) {
+		t.Fatalf("expected %d intents, got %d", len(intents), len(train)+len(test))
+	}
+
+	trainCounts := Balance(train, catalog.Default)
+	for i, class := range Balance(test, catalog.Default) {
+		if class.Count == 0 || trainCounts[i].Count == 0 {
+			t.Errorf("service %d: expected intents on both sides, got %d train and %d test", class.ID, trainCounts[i].Count, class.Count)
+		}
+	}
+
+	if len(test) < 16 || len(test) > 32 {
+		t.Errorf("expected about 20%% of %d intents in test, got %d", len(intents), len(test))
+	}
+
+	again, _ := Split(intents, 0.2, 1)
+	if !reflect.DeepEqual(again, train) {
+		t.Error("expected the same seed to give the same split")
+	}
+
+	if other, _ := Split(intents, 0.2, 2); reflect.DeepEqual(other, train) {
+		t.Error("expected another seed to give another split")
+	}
+
+	if train, test := Split(intents, 0, 1); len(test) != 0 || len(train) != len(intents) {
+		t.Errorf("expected no test intents with fraction 0, got %d", len(test))
+	}
+}
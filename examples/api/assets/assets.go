@@ -0,0 +1,11 @@
+// Package assets embeds the challenge data the service ships with.
+package assets
+
+import _ "embed"
+
+// IntentsPreLoaded is a copy of the repository's
+// assets/intents_pre_loaded.csv, kept next to the service because the
+// Docker build context stops at examples/api.
+//
+//go:embed intents_pre_loaded.csv
+var IntentsPreLoaded []byte
@@ -0,0 +1,230 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBreakerWindow      = 20
+	DefaultBreakerMinRequests = 10
+	DefaultBreakerErrorRate   = 0.5
+	DefaultBreakerSlowCall    = 10 * time.Second
+	DefaultBreakerCooldown    = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without calling OpenRouter while the breaker is
+// open, so callers can switch to a local classifier right away.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of the circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets every request through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails every request with ErrCircuitOpen.
+	BreakerOpen
+	// BreakerHalfOpen lets a single probe through to test the upstream.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+
+	return "unknown"
+}
+
+// BreakerPolicy configures WithCircuitBreaker. Zero values fall back to the
+// defaults.
+type BreakerPolicy struct {
+	// Window is how many recent calls the error rate is computed over.
+	Window int
+	// MinRequests is how many calls the window needs before it can trip.
+	MinRequests int
+	// ErrorRate trips the breaker once this fraction of the window failed.
+	ErrorRate float64
+	// SlowCall counts calls slower than this as failures, even when they
+	// eventually succeed.
+	SlowCall time.Duration
+	// Cooldown is how long the breaker stays open before a probe is let
+	// through.
+	Cooldown time.Duration
+	// OnStateChange, when set, is called on every transition, e.g. to export
+	// the state as a metric.
+	OnStateChange func(from, to BreakerState)
+}
+
+// WithCircuitBreaker stops calling OpenRouter once too many recent calls
+// failed or were slow, returning ErrCircuitOpen instead of waiting out the
+// timeout. After the cooldown a single probe is sent: its success closes the
+// breaker, its failure opens it again. Network errors, timeouts, 429 and 5xx
+// count as failures; requests cancelled by the caller are not counted.
+func WithCircuitBreaker(policy BreakerPolicy) Option {
+	if policy.Window <= 0 {
+		policy.Window = DefaultBreakerWindow
+	}
+	if policy.MinRequests <= 0 {
+		policy.MinRequests = DefaultBreakerMinRequests
+	}
+	if policy.ErrorRate <= 0 {
+		policy.ErrorRate = DefaultBreakerErrorRate
+	}
+	if policy.SlowCall <= 0 {
+		policy.SlowCall = DefaultBreakerSlowCall
+	}
+	if policy.Cooldown <= 0 {
+		policy.Cooldown = DefaultBreakerCooldown
+	}
+
+	b := &breaker{policy: policy, now: time.Now}
+
+	return func(c *Client) {
+		next := c.doFunc
+		c.doFunc = func(c *Client, req *http.Request) (*http.Response, error) {
+			if !b.allow() {
+				return nil, ErrCircuitOpen
+			}
+
+			start := b.now()
+			resp, err := next(c, req)
+
+			if !errors.Is(err, context.Canceled) {
+				b.record(!failedCall(resp, err) && b.now().Sub(start) <= policy.SlowCall)
+			} else {
+				b.abandon()
+			}
+
+			return resp, err
+		}
+	}
+}
+
+type breaker struct {
+	policy BreakerPolicy
+	now    func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	openedAt time.Time
+	probing  bool
+	outcomes []bool
+	pos      int
+	failures int
+}
+
+// allow reports whether a request may go through, moving an open breaker
+// to half-open once the cooldown is over.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.policy.Cooldown {
+			return false
+		}
+		b.transition(BreakerHalfOpen)
+		fallthrough
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+	}
+
+	return true
+}
+
+func (b *breaker) record(ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.probing = false
+		if ok {
+			b.reset()
+			b.transition(BreakerClosed)
+		} else {
+			b.trip()
+		}
+		return
+	}
+
+	if b.state != BreakerClosed {
+		return
+	}
+
+	if len(b.outcomes) < b.policy.Window {
+		b.outcomes = append(b.outcomes, ok)
+	} else {
+		if !b.outcomes[b.pos] {
+			b.failures--
+		}
+		b.outcomes[b.pos] = ok
+		b.pos = (b.pos + 1) % b.policy.Window
+	}
+	if !ok {
+		b.failures++
+	}
+
+	if len(b.outcomes) >= b.policy.MinRequests &&
+		float64(b.failures)/float64(len(b.outcomes)) >= b.policy.ErrorRate {
+		b.trip()
+	}
+}
+
+// abandon releases the probe slot of a request the caller cancelled.
+func (b *breaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.probing = false
+	}
+}
+
+func (b *breaker) trip() {
+	b.reset()
+	b.openedAt = b.now()
+	b.transition(BreakerOpen)
+}
+
+func (b *breaker) reset() {
+	b.outcomes = b.outcomes[:0]
+	b.pos = 0
+	b.failures = 0
+}
+
+func (b *breaker) transition(to BreakerState) {
+	from := b.state
+	if from == to {
+		return
+	}
+
+	b.state = to
+	if b.policy.OnStateChange != nil {
+		b.policy.OnStateChange(from, to)
+	}
+}
+
+// failedCall reports whether a call counts against the breaker. Other 4xx
+// answers come from the request itself, a bad payload or bad credentials,
+// and must not open the circuit for every caller.
+func failedCall(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
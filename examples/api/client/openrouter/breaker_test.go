@@ -0,0 +1,179 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	var (
+		requests atomic.Int32
+		status   atomic.Int32
+		delay    atomic.Int64
+
+		mu          sync.Mutex
+		transitions []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(time.Duration(delay.Load()))
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, WithCircuitBreaker(BreakerPolicy{
+		Window:      4,
+		MinRequests: 4,
+		ErrorRate:   0.5,
+		SlowCall:    50 * time.Millisecond,
+		Cooldown:    100 * time.Millisecond,
+		OnStateChange: func(from, to BreakerState) {
+			mu.Lock()
+			transitions = append(transitions, from.String()+"->"+to.String())
+			mu.Unlock()
+		},
+	}))
+
+	call := func() error {
+		resp, err := client.Do(context.Background(), newTestRequest(t, srv.URL))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	// two failures out of four trip the breaker
+	for _, code := range []int{http.StatusOK, http.StatusServiceUnavailable, http.StatusOK, http.StatusTooManyRequests} {
+		status.Store(int32(code))
+		if err := call(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := call(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("expected the open breaker not to call upstream, got %d requests", got)
+	}
+
+	// a slow probe after the cooldown opens it again
+	time.Sleep(150 * time.Millisecond)
+	status.Store(http.StatusOK)
+	delay.Store(int64(80 * time.Millisecond))
+
+	if err := call(); err != nil {
+		t.Fatalf("unexpected error on probe: %v", err)
+	}
+	if err := call(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the failed probe to reopen the breaker, got %v", err)
+	}
+
+	// a fast probe closes it
+	time.Sleep(150 * time.Millisecond)
+	delay.Store(0)
+
+	for range 3 {
+		if err := call(); err != nil {
+			t.Fatalf("unexpected error after recovery: %v", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("expected transitions %v, got %v", want, transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("expected transitions %v, got %v", want, transitions)
+			break
+		}
+	}
+}
+
+func TestBreaker_SingleProbe(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	b := &breaker{
+		policy: BreakerPolicy{Window: 2, MinRequests: 2, ErrorRate: 1, Cooldown: time.Second},
+		now:    func() time.Time { return now },
+	}
+
+	b.record(false)
+	b.record(false)
+
+	if b.allow() {
+		t.Fatal("expected the breaker to be open")
+	}
+
+	now = now.Add(time.Second)
+
+	if !b.allow() {
+		t.Fatal("expected a probe after the cooldown")
+	}
+	if b.allow() {
+		t.Error("expected a single probe while half-open")
+	}
+
+	// a cancelled probe frees the slot
+	b.abandon()
+	if !b.allow() {
+		t.Error("expected a new probe after the first was abandoned")
+	}
+}
+
+func TestWithCircuitBreaker_IgnoresCancelledCalls(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the server only notices the client going away once the body is read
+		io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, WithCircuitBreaker(BreakerPolicy{Window: 2, MinRequests: 2}))
+
+	for range 3 {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		if _, err := client.Do(ctx, newTestRequest(t, srv.URL)); errors.Is(err, ErrCircuitOpen) {
+			t.Fatal("expected cancelled calls not to trip the breaker")
+		}
+	}
+}
+
+func TestFailedCall(t *testing.T) {
+	tests := []struct {
+		status int
+		want   bool
+	}{
+		{status: http.StatusOK, want: false},
+		{status: http.StatusBadRequest, want: false},
+		{status: http.StatusUnauthorized, want: false},
+		{status: http.StatusPaymentRequired, want: false},
+		{status: http.StatusTooManyRequests, want: true},
+		{status: http.StatusBadGateway, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := failedCall(&http.Response{StatusCode: tt.status}, nil); got != tt.want {
+			t.Errorf("status %d: expected %v, got %v", tt.status, tt.want, got)
+		}
+	}
+
+	if !failedCall(nil, errors.New("connection refused")) {
+		t.Error("expected a network error to count as a failure")
+	}
+}
@@ -0,0 +1,178 @@
+package openrouter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// redacted replaces the value of secret headers in cassettes.
+const redacted = "REDACTED"
+
+// ErrCassetteNotFound is returned in replay mode for a request that was
+// never recorded.
+var ErrCassetteNotFound = errors.New("no cassette recorded for request")
+
+// Cassette is a recorded request/response pair, stored as one JSON file per
+// distinct request.
+type Cassette struct {
+	Request struct {
+		Method string      `json:"method"`
+		URL    string      `json:"url"`
+		Header http.Header `json:"header"`
+		Body   string      `json:"body"`
+	} `json:"request"`
+	Response struct {
+		StatusCode int         `json:"status_code"`
+		Header     http.Header `json:"header"`
+		Body       string      `json:"body"`
+	} `json:"response"`
+}
+
+// WithRecorder saves every request sent through the client transport, with
+// its response, as a cassette in dir. The Authorization header is redacted,
+// so cassettes can be committed.
+func WithRecorder(dir string) Option {
+	return func(c *Client) {
+		c.client.Transport = &recorder{next: c.client.Transport, dir: dir}
+	}
+}
+
+// WithReplay answers requests from the cassettes in dir instead of calling
+// OpenRouter, so tests run offline and deterministically. Requests are
+// matched on method, path and JSON body; a request without a cassette fails
+// with ErrCassetteNotFound.
+func WithReplay(dir string) Option {
+	return func(c *Client) {
+		c.client.Transport = &replayer{dir: dir}
+	}
+}
+
+type recorder struct {
+	next http.RoundTripper
+	dir  string
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	var cassette Cassette
+	cassette.Request.Method = req.Method
+	cassette.Request.URL = req.URL.String()
+	cassette.Request.Header = redactHeader(req.Header)
+	cassette.Request.Body = string(reqBody)
+	cassette.Response.StatusCode = resp.StatusCode
+	cassette.Response.Header = resp.Header
+	cassette.Response.Body = string(respBody)
+
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling cassette: %v", err)
+	}
+
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cassette dir: %w", err)
+	}
+
+	if err := os.WriteFile(cassettePath(r.dir, req, reqBody), data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	return resp, nil
+}
+
+type replayer struct {
+	dir string
+}
+
+func (r *replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	path := cassettePath(r.dir, req, reqBody)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s %s (%s)", ErrCassetteNotFound, req.Method, req.URL, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("error unmarshaling cassette %s: %v", path, err)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cassette.Response.StatusCode, http.StatusText(cassette.Response.StatusCode)),
+		StatusCode:    cassette.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cassette.Response.Header,
+		Body:          io.NopCloser(strings.NewReader(cassette.Response.Body)),
+		ContentLength: int64(len(cassette.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody reads the body and puts it back for the next round
+// tripper.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// cassettePath names the cassette after a hash of the request. The host is
+// left out so cassettes recorded against one base URL replay against
+// another, and the body is normalized so key order and whitespace do not
+// matter.
+func cassettePath(dir string, req *http.Request, body []byte) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.RequestURI() + "\n" + normalizeBody(body)))
+	return filepath.Join(dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+func redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	if header.Get("Authorization") != "" {
+		header.Set("Authorization", redacted)
+	}
+
+	return header
+}
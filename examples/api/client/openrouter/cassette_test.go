@@ -0,0 +1,86 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeContent(w, `{"service_id": 12, "service_name": "Consulta do Saldo"}`)
+	}))
+
+	recording := NewClient(srv.URL, WithAuth("sk-or-secret"), WithRecorder(dir))
+
+	recorded, err := recording.ChatCompletion(context.Background(), "qual meu saldo")
+	if err != nil {
+		t.Fatalf("unexpected error while recording: %v", err)
+	}
+	srv.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 cassette, got %d", len(files))
+	}
+
+	data, _ := os.ReadFile(files[0])
+	if strings.Contains(string(data), "sk-or-secret") {
+		t.Error("expected the Authorization header to be redacted")
+	}
+	if !strings.Contains(string(data), redacted) {
+		t.Error("expected the redacted marker in the cassette")
+	}
+
+	// the server is gone and the base URL differs, the cassette answers
+	replaying := NewClient("http://openrouter.invalid", WithAuth("another-key"), WithReplay(dir))
+
+	replayed, err := replaying.ChatCompletion(context.Background(), "qual meu saldo")
+	if err != nil {
+		t.Fatalf("unexpected error while replaying: %v", err)
+	}
+
+	if !reflect.DeepEqual(replayed, recorded) {
+		t.Errorf("expected replay %+v to match recording %+v", *replayed, *recorded)
+	}
+
+	if _, err := replaying.ChatCompletion(context.Background(), "nunca gravado"); !errors.Is(err, ErrCassetteNotFound) {
+		t.Errorf("expected ErrCassetteNotFound, got %v", err)
+	}
+}
+
+func TestReplay_KeepsStatus(t *testing.T) {
+	dir := t.TempDir()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		io.WriteString(w, `{"error": {"message": "rate limited"}}`)
+	}))
+
+	resp, err := NewClient(srv.URL, WithRecorder(dir)).Do(context.Background(), newTestRequest(t, srv.URL))
+	if err != nil {
+		t.Fatalf("unexpected error while recording: %v", err)
+	}
+	resp.Body.Close()
+	srv.Close()
+
+	resp, err = NewClient("", WithReplay(dir)).Do(context.Background(), newTestRequest(t, "http://openrouter.invalid"))
+	if err != nil {
+		t.Fatalf("unexpected error while replaying: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusTooManyRequests || !strings.Contains(string(body), "rate limited") {
+		t.Errorf("expected the recorded 429 to be replayed, got %d %s", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,93 @@
+package openrouter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Service is one of the fixed services an intent can be routed to.
+type Service struct {
+	ID   uint8  `json:"service_id"`
+	Name string `json:"service_name"`
+}
+
+// Catalog is the list of services the classifier may answer with.
+type Catalog []Service
+
+// DefaultCatalog holds the 16 services listed in the challenge README.
+var DefaultCatalog = Catalog{
+	{ID: 1, Name: "Consulta Limite / Vencimento do cartão / Melhor dia de compra"},
+	{ID: 2, Name: "Segunda via de boleto de acordo"},
+	{ID: 3, Name: "Segunda via de Fatura"},
+	{ID: 4, Name: "Status de Entrega do Cartão"},
+	{ID: 5, Name: "Status de cartão"},
+	{ID: 6, Name: "Solicitação de aumento de limite"},
+	{ID: 7, Name: "Cancelamento de cartão"},
+	{ID: 8, Name: "Telefones de seguradoras"},
+	{ID: 9, Name: "Desbloqueio de Cartão"},
+	{ID: 10, Name: "Esqueceu senha / Troca de senha"},
+	{ID: 11, Name: "Perda e roubo"},
+	{ID: 12, Name: "Consulta do Saldo"},
+	{ID: 13, Name: "Pagamento de contas"},
+	{ID: 14, Name: "Reclamações"},
+	{ID: 15, Name: "Atendimento humano"},
+	{ID: 16, Name: "Token de proposta"},
+}
+
+// Lookup returns the service with the given ID.
+func (c Catalog) Lookup(id uint8) (Service, bool) {
+	for _, s := range c {
+		if s.ID == id {
+			return s, true
+		}
+	}
+
+	return Service{}, false
+}
+
+// SystemPrompt returns the instructions sent to the model along with every
+// intent: the task and the services it may answer with.
+func (c Catalog) SystemPrompt() string {
+	var b strings.Builder
+	b.WriteString("Você é a URA de atendimento de um cartão de crédito. Classifique a frase do cliente em um único serviço")
+	if len(c) == 0 {
+		b.WriteString(".\nResponda apenas com o JSON {\"service_id\": <id>, \"service_name\": \"<nome do serviço>\"}.")
+		return b.String()
+	}
+
+	b.WriteString(", escolhido apenas desta lista (service_id: service_name):\n")
+	for _, s := range c {
+		fmt.Fprintf(&b, "%d: %s\n", s.ID, s.Name)
+	}
+	b.WriteString("Responda apenas com o JSON {\"service_id\": <id>, \"service_name\": \"<nome exatamente como na lista>\"}.")
+
+	return b.String()
+}
+
+// JSONSchema returns the JSON schema of a DataResponse restricted to the
+// catalog: service_id must be one of its IDs and service_name one of its
+// names. It matches the schema exported by the root catalog package.
+func (c Catalog) JSONSchema() map[string]any {
+	ids := make([]int, 0, len(c))
+	names := make([]string, 0, len(c))
+	for _, s := range c {
+		ids = append(ids, int(s.ID))
+		names = append(names, s.Name)
+	}
+
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"service_id": map[string]any{
+				"type": "integer",
+				"enum": ids,
+			},
+			"service_name": map[string]any{
+				"type": "string",
+				"enum": names,
+			},
+		},
+		"required":             []string{"service_id", "service_name"},
+		"additionalProperties": false,
+	}
+}
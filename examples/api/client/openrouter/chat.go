@@ -0,0 +1,73 @@
+package openrouter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrNoModel is returned by Chat when neither the request nor WithModels
+// names a model, instead of sending the placeholder to OpenRouter.
+var ErrNoModel = errors.New("no model set, use WithModels or the request Model")
+
+// Chat sends an arbitrary chat completion through the client options, for
+// prompts other than the classification, e.g. generating training data. An
+// empty Model is filled from WithModels, and Provider from WithProvider;
+// the catalog, output mode and logprobs settings do not apply.
+func (c *Client) Chat(ctx context.Context, request OpenRouterRequest) (*OpenRouterResponse, error) {
+	if request.Model == "" {
+		if c.models[0] == placeholderModel {
+			return nil, ErrNoModel
+		}
+
+		request.Model = c.models[0]
+		if len(c.models) > 1 {
+			request.Models = c.models
+		}
+	}
+	if request.Provider == nil {
+		request.Provider = c.provider
+	}
+
+	jsonBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/chat/completions", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var openRouterResp OpenRouterResponse
+	if err := json.Unmarshal(body, &openRouterResp); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response: %v. body: %s", err, string(body))
+	}
+
+	if len(openRouterResp.Choices) == 0 {
+		return nil, fmt.Errorf("%w: no choices in response", ErrInvalidOutput)
+	}
+
+	return &openRouterResp, nil
+}
@@ -7,92 +7,287 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
 type (
 	OpenRouterRequest struct {
-		Model    string `json:"model"`
-		Messages []struct {
-			Role    string `json:"role"`
-			Content string `json:"content"`
-		} `json:"messages"`
+		Model          string               `json:"model"`
+		Models         []string             `json:"models,omitempty"`
+		Provider       *ProviderPreferences `json:"provider,omitempty"`
+		Messages       []Message            `json:"messages"`
+		ResponseFormat *ResponseFormat      `json:"response_format,omitempty"`
+		Tools          []Tool               `json:"tools,omitempty"`
+		ToolChoice     *ToolChoice          `json:"tool_choice,omitempty"`
+		Usage          *UsageOptions        `json:"usage,omitempty"`
+		Stream         bool                 `json:"stream,omitempty"`
+		Logprobs       bool                 `json:"logprobs,omitempty"`
+		TopLogprobs    int                  `json:"top_logprobs,omitempty"`
+	}
+
+	// UsageOptions enables OpenRouter usage accounting, which adds the
+	// charged cost to the response usage.
+	UsageOptions struct {
+		Include bool `json:"include"`
+	}
+
+	Message struct {
+		Role      string     `json:"role"`
+		Content   string     `json:"content"`
+		ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	}
+
+	// ResponseFormat asks the model for structured output. With Type
+	// "json_schema" models that support it are constrained to JSONSchema.
+	ResponseFormat struct {
+		Type       string      `json:"type"`
+		JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+	}
+
+	JSONSchema struct {
+		Name   string         `json:"name"`
+		Strict bool           `json:"strict"`
+		Schema map[string]any `json:"schema"`
 	}
 
 	OpenRouterResponse struct {
-		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
+		ID       string `json:"id"`
+		Model    string `json:"model"`
+		Provider string `json:"provider"`
+		Choices  []struct {
+			Message  Message   `json:"message"`
+			Logprobs *Logprobs `json:"logprobs"`
 		} `json:"choices"`
+		Usage Usage `json:"usage"`
+	}
+
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+		// Cost is the amount charged in credits (USD), only reported when
+		// usage accounting is enabled.
+		Cost float64 `json:"cost,omitempty"`
 	}
 
 	DataResponse struct {
 		ServiceID   uint8  `json:"service_id"`
 		ServiceName string `json:"service_name"`
+		// Confidence is the probability of ServiceID and Alternatives the
+		// distribution over the catalog, both only set with WithLogprobs.
+		Confidence   float64       `json:"confidence,omitempty"`
+		Alternatives []Alternative `json:"alternatives,omitempty"`
+	}
+
+	// CompletionResult is the classification together with the metadata of
+	// the call that produced it.
+	CompletionResult struct {
+		Data     *DataResponse
+		ID       string
+		Model    string
+		Provider string
+		Usage    Usage
+		// Cost is computed from WithPricing when the model has a price and
+		// falls back to Usage.Cost otherwise.
+		Cost    float64
+		Latency time.Duration
 	}
 )
 
+// ChatCompletion classifies intent. See Complete for the call metadata.
 func (c *Client) ChatCompletion(ctx context.Context, intent string) (*DataResponse, error) {
+	result, err := c.Complete(ctx, intent)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// Complete classifies intent and reports the model, provider, token usage,
+// cost and latency of the call. When the client has fallback models, a
+// timeout or an unparsable answer moves on to the next one.
+func (c *Client) Complete(ctx context.Context, intent string) (*CompletionResult, error) {
+	chains := [][]string{c.models}
+	for _, model := range c.fallbackModels {
+		chains = append(chains, []string{model})
+	}
+
+	for i := 0; ; i++ {
+		last := i == len(chains)-1
+
+		attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+		if c.fallbackTimeout > 0 && !last {
+			attemptCtx, cancel = context.WithTimeout(ctx, c.fallbackTimeout)
+		}
+
+		result, err := c.complete(attemptCtx, chains[i], intent)
+		cancel()
+
+		if err == nil || last || !shouldFallback(ctx, err) {
+			return result, err
+		}
+	}
+}
+
+// complete sends a single chat completion. The first model is the requested
+// one, the others are left to OpenRouter as its own fallbacks.
+func (c *Client) complete(ctx context.Context, models []string, intent string) (*CompletionResult, error) {
+	req, err := c.newChatRequest(ctx, models, intent, false)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	latency := time.Since(start)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var openRouterResp OpenRouterResponse
+	if err := json.Unmarshal(body, &openRouterResp); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response: %v. body: %s", err, string(body))
+	}
+
+	if len(openRouterResp.Choices) == 0 {
+		return nil, fmt.Errorf("%w: no choices in response", ErrInvalidOutput)
+	}
+
+	choice := openRouterResp.Choices[0]
+
+	data, err := c.parseMessage(choice.Message)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOutput, err)
+	}
+
+	if c.topLogprobs > 0 {
+		c.catalog.applyLogprobs(data, choice.Message.Content, choice.Logprobs)
+	}
+
+	return &CompletionResult{
+		Data:     data,
+		ID:       openRouterResp.ID,
+		Model:    openRouterResp.Model,
+		Provider: openRouterResp.Provider,
+		Usage:    openRouterResp.Usage,
+		Cost:     c.cost(openRouterResp.Model, openRouterResp.Usage),
+		Latency:  latency,
+	}, nil
+}
+
+// newChatRequest builds the chat completion request for intent, constrained
+// by the client output mode.
+func (c *Client) newChatRequest(ctx context.Context, models []string, intent string, stream bool) (*http.Request, error) {
 	url := c.baseURL + "/chat/completions"
 
 	requestBody := OpenRouterRequest{
-		Model: "<definir_modelo>",
-		Messages: []struct {
-			Role    string `json:"role"`
-			Content string `json:"content"`
-		}{
+		Model:    models[0],
+		Provider: c.provider,
+		Messages: []Message{
 			{
-				Role: "system",
-				Content: `Aqui você define as instruções para o modelo de linguagem, 
-        incluindo o comportamento esperado, o formato da resposta e quaisquer diretrizes específicas 
-        que ele deve seguir ao processar as solicitações dos usuários.`,
+				Role:    "system",
+				Content: c.catalog.SystemPrompt(),
 			},
 			{
 				Role:    "user",
 				Content: intent,
 			},
 		},
+		Usage:  &UsageOptions{Include: true},
+		Stream: stream,
+	}
+	if c.topLogprobs > 0 && !stream {
+		requestBody.Logprobs = true
+		requestBody.TopLogprobs = c.topLogprobs
 	}
+	if len(models) > 1 {
+		requestBody.Models = models
+	}
+	c.constrainOutput(&requestBody)
 
 	jsonBody, err := json.Marshal(requestBody)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling request: %v", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.Do(ctx, req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %v", err)
+	return req, nil
+}
+
+func (c *Client) parseMessage(message Message) (*DataResponse, error) {
+	if c.outputMode == OutputToolCall && len(c.catalog) > 0 {
+		return c.catalog.parseRouteCall(message.ToolCalls)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response: %v", err)
+	data, err := parseDataResponse(message.Content)
+	if err != nil || len(c.catalog) == 0 {
+		return data, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	// the schema only constrains models that support structured output
+	if err := c.catalog.check(data); err != nil {
+		return nil, err
 	}
 
-	var openRouterResp OpenRouterResponse
-	if err := json.Unmarshal(body, &openRouterResp); err != nil {
-		return nil, fmt.Errorf("error unmarshaling response: %v. body: %s", err, string(body))
+	return data, nil
+}
+
+// constrainOutput sets up the request for the client output mode: either a
+// json_schema response format or a forced route_call tool, both built from
+// the client catalog. Without a catalog the request is left unconstrained.
+func (c *Client) constrainOutput(req *OpenRouterRequest) {
+	if len(c.catalog) == 0 {
+		return
 	}
 
-	if len(openRouterResp.Choices) == 0 {
-		return nil, fmt.Errorf("no choices in response")
+	switch c.outputMode {
+	case OutputToolCall:
+		req.Tools = []Tool{c.catalog.RouteCallTool()}
+		req.ToolChoice = &ToolChoice{
+			Type:     "function",
+			Function: ToolChoiceFunction{Name: RouteCallToolName},
+		}
+	default:
+		req.ResponseFormat = &ResponseFormat{
+			Type: "json_schema",
+			JSONSchema: &JSONSchema{
+				Name:   "service_classification",
+				Strict: true,
+				Schema: c.catalog.JSONSchema(),
+			},
+		}
 	}
+}
 
+// parseDataResponse decodes the model answer. Content that is not plain JSON
+// is retried through extractJSON, for models that ignore the schema.
+func parseDataResponse(content string) (*DataResponse, error) {
 	var dataRes DataResponse
-	if err := json.Unmarshal([]byte(openRouterResp.Choices[0].Message.Content), &dataRes); err != nil {
-		return nil, fmt.Errorf("error unmarshaling data response: %v. content: %s", err, openRouterResp.Choices[0].Message.Content)
+	if err := json.Unmarshal([]byte(content), &dataRes); err == nil {
+		return &dataRes, nil
+	}
+
+	if err := json.Unmarshal([]byte(extractJSON(content)), &dataRes); err != nil {
+		return nil, fmt.Errorf("error unmarshaling data response: %v. content: %s", err, content)
 	}
 
 	return &dataRes, nil
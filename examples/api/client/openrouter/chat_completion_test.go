@@ -0,0 +1,165 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "plain object",
+			input: `{"service_id": 3, "service_name": "Segunda via de Fatura"}`,
+			want:  `{"service_id": 3, "service_name": "Segunda via de Fatura"}`,
+		},
+		{
+			name:  "code fence",
+			input: "```json\n{\"service_id\": 3}\n```",
+			want:  `{"service_id": 3}`,
+		},
+		{
+			name:  "surrounded by prose",
+			input: `Claro! A resposta é {"service_id": 12} pois fala de saldo.`,
+			want:  `{"service_id": 12}`,
+		},
+		{
+			name:  "braces in prose before the object",
+			input: `Formato {id, nome}: {"service_id": 7, "service_name": "Cancelamento de cartão"} {fim}`,
+			want:  `{"service_id": 7, "service_name": "Cancelamento de cartão"}`,
+		},
+		{
+			name:  "no object",
+			input: "  não sei  ",
+			want:  "não sei",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractJSON(tt.input); got != tt.want {
+				t.Errorf("extractJSON(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalogJSONSchema(t *testing.T) {
+	schema := Catalog{{ID: 2, Name: "b"}, {ID: 1, Name: "a"}, {ID: 5, Name: "c"}}.JSONSchema()
+
+	properties := schema["properties"].(map[string]any)
+
+	id := properties["service_id"].(map[string]any)
+	if want := []int{2, 1, 5}; !reflect.DeepEqual(id["enum"], want) {
+		t.Errorf("expected service_id enum %v, got %v", want, id["enum"])
+	}
+
+	name := properties["service_name"].(map[string]any)
+	if want := []string{"b", "a", "c"}; !reflect.DeepEqual(name["enum"], want) {
+		t.Errorf("expected service_name enum %v, got %v", want, name["enum"])
+	}
+}
+
+func TestChatCompletion(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		content string
+		want    DataResponse
+		wantErr bool
+		schema  bool
+	}{
+		{
+			name:    "structured output",
+			content: `{"service_id": 12, "service_name": "Consulta do Saldo"}`,
+			want:    DataResponse{ServiceID: 12, ServiceName: "Consulta do Saldo"},
+			schema:  true,
+		},
+		{
+			name:    "model ignores the schema",
+			content: "Aqui está:\n```json\n{\"service_id\": 11, \"service_name\": \"Perda e roubo\"}\n```",
+			want:    DataResponse{ServiceID: 11, ServiceName: "Perda e roubo"},
+			schema:  true,
+		},
+		{
+			name:    "without catalog",
+			opts:    []Option{WithCatalog(nil)},
+			content: `{"service_id": 15, "service_name": "Atendimento humano"}`,
+			want:    DataResponse{ServiceID: 15, ServiceName: "Atendimento humano"},
+		},
+		{
+			name:    "service outside the catalog",
+			content: `{"service_id": 42, "service_name": "Cartões de Crédito"}`,
+			wantErr: true,
+			schema:  true,
+		},
+		{
+			name:    "name of another service",
+			content: `{"service_id": 2, "service_name": "Segunda via de Fatura"}`,
+			wantErr: true,
+			schema:  true,
+		},
+		{
+			name:    "no JSON at all",
+			content: "Não consegui classificar.",
+			wantErr: true,
+			schema:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req OpenRouterRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Errorf("failed to decode request: %v", err)
+				}
+
+				if len(req.Messages) == 0 || req.Messages[0].Role != "system" {
+					t.Fatalf("expected a system prompt first, got %+v", req.Messages)
+				}
+				if got := strings.Contains(req.Messages[0].Content, "11: Perda e roubo"); got != tt.schema {
+					t.Errorf("expected the system prompt to list the catalog: %v, got %q", tt.schema, req.Messages[0].Content)
+				}
+
+				if got := req.ResponseFormat != nil; got != tt.schema {
+					t.Errorf("expected response_format to be sent: %v, got %+v", tt.schema, req.ResponseFormat)
+				}
+				if req.ResponseFormat != nil && (req.ResponseFormat.Type != "json_schema" || req.ResponseFormat.JSONSchema == nil) {
+					t.Errorf("expected a json_schema response format, got %+v", req.ResponseFormat)
+				}
+
+				json.NewEncoder(w).Encode(map[string]any{
+					"choices": []any{map[string]any{"message": map[string]any{"content": tt.content}}},
+				})
+			}))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, tt.opts...)
+
+			got, err := client.ChatCompletion(context.Background(), "perdi meu cartão")
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidOutput) {
+					t.Fatalf("expected error %v, got %+v, %v", ErrInvalidOutput, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, *got)
+			}
+		})
+	}
+}
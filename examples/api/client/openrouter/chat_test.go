@@ -0,0 +1,121 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestChat(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []Option
+		request    OpenRouterRequest
+		status     int
+		response   string
+		wantModel  string
+		wantModels []string
+		wantErrIs  error
+		wantErr    bool
+	}{
+		{
+			name:      "model from the client",
+			opts:      []Option{WithModels("openai/gpt-4o-mini")},
+			request:   OpenRouterRequest{Messages: []Message{{Role: "user", Content: "oi"}}},
+			status:    http.StatusOK,
+			response:  `{"model": "openai/gpt-4o-mini", "choices": [{"message": {"role": "assistant", "content": "olá"}}]}`,
+			wantModel: "openai/gpt-4o-mini",
+		},
+		{
+			name:       "client fallbacks",
+			opts:       []Option{WithModels("primary", "secondary")},
+			request:    OpenRouterRequest{Messages: []Message{{Role: "user", Content: "oi"}}},
+			status:     http.StatusOK,
+			response:   `{"choices": [{"message": {"role": "assistant", "content": "olá"}}]}`,
+			wantModel:  "primary",
+			wantModels: []string{"primary", "secondary"},
+		},
+		{
+			name:      "model from the request",
+			opts:      []Option{WithModels("openai/gpt-4o-mini")},
+			request:   OpenRouterRequest{Model: "google/gemini-2.0-flash-001"},
+			status:    http.StatusOK,
+			response:  `{"choices": [{"message": {"role": "assistant", "content": "olá"}}]}`,
+			wantModel: "google/gemini-2.0-flash-001",
+		},
+		{
+			name:      "no model",
+			request:   OpenRouterRequest{Messages: []Message{{Role: "user", Content: "oi"}}},
+			wantErr:   true,
+			wantErrIs: ErrNoModel,
+		},
+		{
+			name:      "no choices",
+			opts:      []Option{WithModels("openai/gpt-4o-mini")},
+			status:    http.StatusOK,
+			response:  `{"choices": []}`,
+			wantModel: "openai/gpt-4o-mini",
+			wantErr:   true,
+			wantErrIs: ErrInvalidOutput,
+		},
+		{
+			name:      "API error",
+			opts:      []Option{WithModels("openai/unknown")},
+			status:    http.StatusBadRequest,
+			response:  `{"error": {"message": "invalid model"}}`,
+			wantModel: "openai/unknown",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got OpenRouterRequest
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/chat/completions" {
+					t.Errorf("expected path /chat/completions, got %s", r.URL.Path)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("failed to decode request: %v", err)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.response))
+			}))
+			defer srv.Close()
+
+			resp, err := NewClient(srv.URL, tt.opts...).Chat(context.Background(), tt.request)
+
+			if got.Model != tt.wantModel {
+				t.Errorf("expected model %s, got %s", tt.wantModel, got.Model)
+			}
+			if !reflect.DeepEqual(got.Models, tt.wantModels) {
+				t.Errorf("expected models %v, got %v", tt.wantModels, got.Models)
+			}
+			if got.ResponseFormat != nil || got.Tools != nil {
+				t.Errorf("expected no classification constraints, got %+v", got)
+			}
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("expected error %v, got %v", tt.wantErrIs, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if content := resp.Choices[0].Message.Content; content != "olá" {
+				t.Errorf("expected content olá, got %s", content)
+			}
+		})
+	}
+}
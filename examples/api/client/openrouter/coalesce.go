@@ -0,0 +1,165 @@
+package openrouter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// WithCoalescing makes concurrent identical requests share a single upstream
+// call. Requests are identical when they have the same method, URL and JSON
+// body, regardless of key order and whitespace. Every caller gets its own
+// copy of the response and keeps its own cancellation: the shared call is
+// only cancelled once all of its callers gave up. Streamed requests are
+// never coalesced.
+func WithCoalescing() Option {
+	g := &coalescer{calls: make(map[string]*sharedCall)}
+
+	return func(c *Client) {
+		next := c.doFunc
+		c.doFunc = func(c *Client, req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Accept") == "text/event-stream" {
+				return next(c, req)
+			}
+
+			return g.do(c, req, next)
+		}
+	}
+}
+
+type coalescer struct {
+	mu    sync.Mutex
+	calls map[string]*sharedCall
+}
+
+type sharedCall struct {
+	done   chan struct{}
+	cancel context.CancelFunc
+
+	// guarded by coalescer.mu
+	waiters int
+
+	// set before done is closed
+	status int
+	header http.Header
+	body   []byte
+	err    error
+}
+
+func (g *coalescer) do(c *Client, req *http.Request, next func(*Client, *http.Request) (*http.Response, error)) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	key := req.Method + " " + req.URL.String() + "\n" + normalizeBody(body)
+	ctx := req.Context()
+
+	g.mu.Lock()
+	call, ok := g.calls[key]
+	if !ok {
+		call = &sharedCall{done: make(chan struct{})}
+		g.calls[key] = call
+
+		// the upstream call outlives the caller that started it, but keeps
+		// its deadline
+		upstream, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		if deadline, ok := ctx.Deadline(); ok {
+			upstream, cancel = context.WithDeadline(context.WithoutCancel(ctx), deadline)
+		}
+		call.cancel = cancel
+
+		go g.run(c, key, call, req.WithContext(upstream), next)
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// nobody is left to read the answer; later callers start over
+			call.cancel()
+			g.forget(key, call)
+		}
+		g.mu.Unlock()
+
+		return nil, ctx.Err()
+	}
+
+	if call.err != nil {
+		return nil, call.err
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(call.status),
+		StatusCode:    call.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        call.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(call.body)),
+		ContentLength: int64(len(call.body)),
+		Request:       req,
+	}, nil
+}
+
+func (g *coalescer) run(c *Client, key string, call *sharedCall, req *http.Request, next func(*Client, *http.Request) (*http.Response, error)) {
+	defer call.cancel()
+
+	resp, err := next(c, req)
+	if err == nil {
+		call.status, call.header = resp.StatusCode, resp.Header
+		call.body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+	call.err = err
+
+	// callers arriving from now on start a new call
+	g.mu.Lock()
+	g.forget(key, call)
+	g.mu.Unlock()
+
+	close(call.done)
+}
+
+// forget removes call from the in-flight calls, unless it was already
+// replaced. g.mu must be held.
+func (g *coalescer) forget(key string, call *sharedCall) {
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
+
+// normalizeBody re-encodes a JSON body so that key order and whitespace do
+// not matter. Other bodies are used as they are.
+func normalizeBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return string(body)
+	}
+
+	normalized, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+
+	return string(normalized)
+}
@@ -0,0 +1,146 @@
+package openrouter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithCoalescing(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		<-release
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, WithCoalescing())
+
+	bodies := []string{
+		`{"model": "m", "intent": "segunda via"}`,
+		`{"intent":"segunda via","model":"m"}`,
+		`{"model": "m", "intent": "segunda via"}`,
+		`{"model": "m", "intent": "cancelar"}`,
+	}
+
+	var wg sync.WaitGroup
+	answers := make([]string, len(bodies))
+
+	for i, body := range bodies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req, _ := http.NewRequest(http.MethodPost, srv.URL+"/chat/completions", bytes.NewBufferString(body))
+
+			resp, err := client.Do(context.Background(), req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			defer resp.Body.Close()
+
+			answer, _ := io.ReadAll(resp.Body)
+			answers[i] = string(answer)
+		}()
+	}
+
+	// let all callers join before the upstream answers
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 upstream calls, got %d", got)
+	}
+
+	if answers[0] != answers[1] || answers[1] != answers[2] {
+		t.Errorf("expected identical requests to share the answer, got %q", answers[:3])
+	}
+	if answers[3] != bodies[3] {
+		t.Errorf("expected the distinct request to get its own answer, got %q", answers[3])
+	}
+}
+
+func TestWithCoalescing_Cancellation(t *testing.T) {
+	var (
+		requests  atomic.Int32
+		cancelled = make(chan struct{})
+	)
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		io.Copy(io.Discard, r.Body)
+
+		select {
+		case <-release:
+			w.Write([]byte("ok"))
+		case <-r.Context().Done():
+			if n == 2 {
+				close(cancelled)
+			}
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, WithCoalescing())
+
+	send := func(ctx context.Context) error {
+		resp, err := client.Do(ctx, newTestRequest(t, srv.URL))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	// the caller that started the call gives up, the other one still gets
+	// the answer
+	first, cancelFirst := context.WithCancel(context.Background())
+
+	errs := make(chan error, 2)
+	go func() { errs <- send(first) }()
+	time.Sleep(20 * time.Millisecond)
+	go func() { errs <- send(context.Background()) }()
+	time.Sleep(20 * time.Millisecond)
+
+	cancelFirst()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancelled caller to get context.Canceled, got %v", err)
+	}
+
+	close(release)
+	if err := <-errs; err != nil {
+		t.Errorf("expected the remaining caller to succeed, got %v", err)
+	}
+
+	// once every caller gave up the upstream call is cancelled
+	release = make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { errs <- send(ctx) }()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("expected the abandoned upstream call to be cancelled")
+	}
+}
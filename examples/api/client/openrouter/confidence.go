@@ -0,0 +1,147 @@
+package openrouter
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// MaxTopLogprobs is the largest top_logprobs value the API accepts.
+const MaxTopLogprobs = 20
+
+var digitsRe = regexp.MustCompile(`\d+`)
+
+type (
+	// Logprobs holds the log probability of every generated content token.
+	Logprobs struct {
+		Content []TokenLogprob `json:"content"`
+	}
+
+	TokenLogprob struct {
+		Token       string       `json:"token"`
+		Logprob     float64      `json:"logprob"`
+		TopLogprobs []TopLogprob `json:"top_logprobs"`
+	}
+
+	TopLogprob struct {
+		Token   string  `json:"token"`
+		Logprob float64 `json:"logprob"`
+	}
+
+	// Alternative is a candidate service with the probability the model gave
+	// it.
+	Alternative struct {
+		ServiceID   uint8   `json:"service_id"`
+		ServiceName string  `json:"service_name"`
+		Probability float64 `json:"probability"`
+	}
+)
+
+// WithLogprobs requests the top n alternatives of every token, capped at
+// MaxTopLogprobs, and fills DataResponse.Confidence and Alternatives from the
+// token carrying the service_id. Models without logprobs support answer as
+// usual and leave both empty. Tool call answers carry no logprobs.
+func WithLogprobs(n int) Option {
+	return func(c *Client) {
+		c.topLogprobs = max(0, min(n, MaxTopLogprobs))
+	}
+}
+
+// applyLogprobs sets the confidence of data from the logprobs of content.
+func (c Catalog) applyLogprobs(data *DataResponse, content string, logprobs *Logprobs) {
+	if logprobs == nil {
+		return
+	}
+
+	alternatives := c.serviceDistribution(content, logprobs.Content)
+	if len(alternatives) == 0 {
+		return
+	}
+
+	data.Alternatives = alternatives
+	for _, alt := range alternatives {
+		if alt.ServiceID == data.ServiceID {
+			data.Confidence = alt.Probability
+		}
+	}
+}
+
+// serviceDistribution finds the token where the service_id starts in the
+// answer and turns its top alternatives into a probability distribution over
+// the catalog, most likely first. Alternatives that are not a catalog ID are
+// dropped before normalizing. IDs are assumed to be a single token, which
+// holds for the common tokenizers that keep up to three digits together.
+func (c Catalog) serviceDistribution(content string, tokens []TokenLogprob) []Alternative {
+	m := serviceIDFieldRe.FindStringSubmatchIndex(content)
+	if m == nil {
+		m = bareServiceIDRe.FindStringSubmatchIndex(content)
+	}
+	if m == nil {
+		return nil
+	}
+
+	token, ok := tokenAt(tokens, m[2])
+	if !ok {
+		return nil
+	}
+
+	candidates := token.TopLogprobs
+	if len(candidates) == 0 {
+		candidates = []TopLogprob{{Token: token.Token, Logprob: token.Logprob}}
+	}
+
+	probabilities := make(map[uint8]float64)
+
+	var total float64
+	for _, candidate := range candidates {
+		id, err := strconv.ParseUint(digitsRe.FindString(candidate.Token), 10, 8)
+		if err != nil {
+			continue
+		}
+		if _, ok := c.Lookup(uint8(id)); !ok {
+			continue
+		}
+
+		// " 7" and "7" are different tokens for the same service
+		p := math.Exp(candidate.Logprob)
+		probabilities[uint8(id)] += p
+		total += p
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	alternatives := make([]Alternative, 0, len(probabilities))
+	for id, p := range probabilities {
+		service, _ := c.Lookup(id)
+		alternatives = append(alternatives, Alternative{
+			ServiceID:   id,
+			ServiceName: service.Name,
+			Probability: p / total,
+		})
+	}
+
+	sort.Slice(alternatives, func(i, j int) bool {
+		if alternatives[i].Probability != alternatives[j].Probability {
+			return alternatives[i].Probability > alternatives[j].Probability
+		}
+		return alternatives[i].ServiceID < alternatives[j].ServiceID
+	})
+
+	return alternatives
+}
+
+// tokenAt returns the token covering byte offset pos of the answer.
+func tokenAt(tokens []TokenLogprob, pos int) (TokenLogprob, bool) {
+	offset := 0
+	for _, token := range tokens {
+		offset += len(token.Token)
+		if pos < offset {
+			return token, true
+		}
+	}
+
+	return TokenLogprob{}, false
+}
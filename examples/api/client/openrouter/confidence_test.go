@@ -0,0 +1,118 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServiceDistribution(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		tokens  []TokenLogprob
+		want    map[uint8]float64
+	}{
+		{
+			name:    "bare number",
+			content: "7",
+			tokens: []TokenLogprob{{Token: "7", Logprob: math.Log(0.6), TopLogprobs: []TopLogprob{
+				{Token: "7", Logprob: math.Log(0.6)},
+				{Token: "11", Logprob: math.Log(0.3)},
+				{Token: "Desculpe", Logprob: math.Log(0.1)},
+			}}},
+			want: map[uint8]float64{7: 0.6 / 0.9, 11: 0.3 / 0.9},
+		},
+		{
+			name:    "JSON answer uses the service_id token",
+			content: `{"service_id": 3}`,
+			tokens: []TokenLogprob{
+				{Token: `{"`, Logprob: 0},
+				{Token: `service_id`, Logprob: 0},
+				{Token: `":`, Logprob: 0},
+				{Token: ` 3`, Logprob: math.Log(0.5), TopLogprobs: []TopLogprob{
+					{Token: " 3", Logprob: math.Log(0.5)},
+					{Token: "3", Logprob: math.Log(0.25)},
+					{Token: " 13", Logprob: math.Log(0.25)},
+				}},
+				{Token: `}`, Logprob: 0},
+			},
+			want: map[uint8]float64{3: 0.75, 13: 0.25},
+		},
+		{
+			name:    "IDs outside the catalog are dropped",
+			content: "42",
+			tokens:  []TokenLogprob{{Token: "42", Logprob: math.Log(0.9)}},
+			want:    map[uint8]float64{},
+		},
+		{
+			name:    "no service_id",
+			content: "não sei",
+			tokens:  []TokenLogprob{{Token: "não sei", Logprob: 0}},
+			want:    map[uint8]float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DefaultCatalog.serviceDistribution(tt.content, tt.tokens)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d alternatives, got %+v", len(tt.want), got)
+			}
+
+			for i, alt := range got {
+				if math.Abs(alt.Probability-tt.want[alt.ServiceID]) > 1e-9 {
+					t.Errorf("expected service %d to have probability %v, got %v", alt.ServiceID, tt.want[alt.ServiceID], alt.Probability)
+				}
+				if i > 0 && alt.Probability > got[i-1].Probability {
+					t.Errorf("expected alternatives sorted by probability, got %+v", got)
+				}
+			}
+		})
+	}
+}
+
+func TestChatCompletion_Logprobs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req OpenRouterRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		if !req.Logprobs || req.TopLogprobs != MaxTopLogprobs {
+			t.Errorf("expected logprobs with top %d, got %v/%d", MaxTopLogprobs, req.Logprobs, req.TopLogprobs)
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"choices": []any{map[string]any{
+				"message": map[string]any{"content": `{"service_id": 15, "service_name": "Atendimento humano"}`},
+				"logprobs": Logprobs{Content: []TokenLogprob{
+					{Token: `{"service_id": `},
+					{Token: "15", Logprob: math.Log(0.8), TopLogprobs: []TopLogprob{
+						{Token: "15", Logprob: math.Log(0.8)},
+						{Token: "14", Logprob: math.Log(0.2)},
+					}},
+					{Token: `, "service_name": "Atendimento humano"}`},
+				}},
+			}},
+		})
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, WithLogprobs(50))
+
+	got, err := client.ChatCompletion(context.Background(), "quero falar com uma pessoa")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if math.Abs(got.Confidence-0.8) > 1e-9 {
+		t.Errorf("expected confidence 0.8, got %v", got.Confidence)
+	}
+
+	if len(got.Alternatives) != 2 || got.Alternatives[1].ServiceID != 14 || got.Alternatives[1].ServiceName != "Reclamações" {
+		t.Errorf("unexpected alternatives: %+v", got.Alternatives)
+	}
+}
@@ -0,0 +1,135 @@
+package openrouter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const DefaultEmbeddingBatchSize = 100
+
+type (
+	EmbeddingsRequest struct {
+		Model string   `json:"model"`
+		Input []string `json:"input"`
+	}
+
+	EmbeddingsResponse struct {
+		Model string      `json:"model"`
+		Data  []Embedding `json:"data"`
+		Usage Usage       `json:"usage"`
+	}
+
+	Embedding struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	}
+
+	// EmbeddingsResult holds one vector per input, in input order, and the
+	// usage summed over all batches.
+	EmbeddingsResult struct {
+		Model      string
+		Embeddings [][]float64
+		Usage      Usage
+		Cost       float64
+	}
+)
+
+// WithEmbeddingBatchSize sets how many inputs Embeddings sends per request,
+// DefaultEmbeddingBatchSize by default.
+func WithEmbeddingBatchSize(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.embeddingBatchSize = n
+		}
+	}
+}
+
+// Embeddings returns the embedding of every input using model. Inputs are
+// sent in batches, each one going through the client options like any chat
+// completion.
+func (c *Client) Embeddings(ctx context.Context, model string, inputs []string) (*EmbeddingsResult, error) {
+	result := &EmbeddingsResult{
+		Model:      model,
+		Embeddings: make([][]float64, len(inputs)),
+	}
+
+	for start := 0; start < len(inputs); start += c.embeddingBatchSize {
+		end := min(start+c.embeddingBatchSize, len(inputs))
+
+		batch, err := c.embed(ctx, model, inputs[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		if len(batch.Data) != end-start {
+			return nil, fmt.Errorf("expected %d embeddings, got %d", end-start, len(batch.Data))
+		}
+
+		seen := make([]bool, end-start)
+		for _, e := range batch.Data {
+			if e.Index < 0 || e.Index >= end-start {
+				return nil, fmt.Errorf("embedding index %d out of range", e.Index)
+			}
+			// the count matches, so a repeated index means another one is
+			// missing and its input would be left without an embedding
+			if seen[e.Index] {
+				return nil, fmt.Errorf("duplicate embedding index %d", e.Index)
+			}
+			seen[e.Index] = true
+			result.Embeddings[start+e.Index] = e.Embedding
+		}
+
+		if batch.Model != "" {
+			result.Model = batch.Model
+		}
+		result.Usage.PromptTokens += batch.Usage.PromptTokens
+		result.Usage.TotalTokens += batch.Usage.TotalTokens
+		result.Usage.Cost += batch.Usage.Cost
+	}
+
+	result.Cost = c.cost(result.Model, result.Usage)
+
+	return result, nil
+}
+
+func (c *Client) embed(ctx context.Context, model string, inputs []string) (*EmbeddingsResponse, error) {
+	url := c.baseURL + "/embeddings"
+
+	jsonBody, err := json.Marshal(EmbeddingsRequest{Model: model, Input: inputs})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var embeddingsResp EmbeddingsResponse
+	if err := json.Unmarshal(body, &embeddingsResp); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response: %v. body: %s", err, string(body))
+	}
+
+	return &embeddingsResp, nil
+}
@@ -0,0 +1,105 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestEmbeddings(t *testing.T) {
+	var (
+		requests atomic.Int32
+		observed atomic.Int32
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.URL.Path != "/embeddings" {
+			t.Errorf("expected /embeddings, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Error("expected the auth header")
+		}
+
+		var req EmbeddingsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if len(req.Input) > 2 {
+			t.Errorf("expected batches of at most 2 inputs, got %d", len(req.Input))
+		}
+
+		// answer in reverse order, the index decides the position
+		resp := EmbeddingsResponse{Model: req.Model, Usage: Usage{PromptTokens: len(req.Input), TotalTokens: len(req.Input)}}
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			resp.Data = append(resp.Data, Embedding{Index: i, Embedding: []float64{float64(len(req.Input[i]))}})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL,
+		WithAuth("test-token"),
+		WithEmbeddingBatchSize(2),
+		WithObserver(func(Observation) { observed.Add(1) }),
+	)
+
+	inputs := []string{"a", "bb", "ccc", "dddd", "eeeee"}
+
+	result, err := client.Embeddings(context.Background(), "openai/text-embedding-3-small", inputs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := [][]float64{{1}, {2}, {3}, {4}, {5}}
+	if !reflect.DeepEqual(result.Embeddings, want) {
+		t.Errorf("expected embeddings %v, got %v", want, result.Embeddings)
+	}
+
+	if result.Usage.PromptTokens != 5 {
+		t.Errorf("expected usage summed over batches, got %+v", result.Usage)
+	}
+
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 batches, got %d", got)
+	}
+	if got := observed.Load(); got != 3 {
+		t.Errorf("expected every batch to be observed, got %d", got)
+	}
+}
+
+func TestEmbeddings_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+
+	if _, err := client.Embeddings(context.Background(), "unknown", []string{"a"}); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestEmbeddings_DuplicateIndex(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(EmbeddingsResponse{Data: []Embedding{
+			{Index: 0, Embedding: []float64{1}},
+			{Index: 0, Embedding: []float64{2}},
+		}})
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+
+	_, err := client.Embeddings(context.Background(), "openai/text-embedding-3-small", []string{"a", "b"})
+	if err == nil || !strings.Contains(err.Error(), "duplicate embedding index 0") {
+		t.Errorf("expected a duplicate index error, got %v", err)
+	}
+}
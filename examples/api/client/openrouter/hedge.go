@@ -0,0 +1,247 @@
+package openrouter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultHedgePercentile   = 95
+	DefaultHedgeInitialDelay = time.Second
+	DefaultHedgeWindow       = 100
+	DefaultHedgeMaxInFlight  = 4
+
+	// minHedgeSamples is how many latencies are needed before the percentile
+	// replaces InitialDelay
+	minHedgeSamples = 10
+)
+
+// HedgePolicy configures WithHedging. Zero values fall back to the defaults.
+type HedgePolicy struct {
+	// Percentile of the recent latencies after which the hedge is sent.
+	Percentile float64
+	// InitialDelay is used until enough latencies have been recorded.
+	InitialDelay time.Duration
+	// Window is how many recent latencies are kept.
+	Window int
+	// AlternateModel, when set, replaces the model of the hedged request so
+	// a slow model is raced against a different one.
+	AlternateModel string
+	// MaxInFlight caps the hedges running at the same time across all
+	// callers, so a slow upstream does not double the load.
+	MaxInFlight int
+}
+
+// WithHedging sends a second request when the first one has not answered
+// within the policy percentile of recent latencies, and returns whichever
+// answers first. The loser is cancelled through its context. Responses with
+// a 5xx status or a network error do not win while the other request is
+// still running.
+func WithHedging(policy HedgePolicy) Option {
+	if policy.Percentile <= 0 || policy.Percentile > 100 {
+		policy.Percentile = DefaultHedgePercentile
+	}
+	if policy.InitialDelay <= 0 {
+		policy.InitialDelay = DefaultHedgeInitialDelay
+	}
+	if policy.Window <= 0 {
+		policy.Window = DefaultHedgeWindow
+	}
+	if policy.MaxInFlight <= 0 {
+		policy.MaxInFlight = DefaultHedgeMaxInFlight
+	}
+
+	h := &hedger{policy: policy}
+
+	return func(c *Client) {
+		next := c.doFunc
+		c.doFunc = func(c *Client, req *http.Request) (*http.Response, error) {
+			return h.do(c, req, next)
+		}
+	}
+}
+
+type hedger struct {
+	policy   HedgePolicy
+	inFlight atomic.Int32
+
+	mu        sync.Mutex
+	latencies []time.Duration
+	pos       int
+}
+
+type hedgeResult struct {
+	attempt int
+	resp    *http.Response
+	err     error
+	latency time.Duration
+}
+
+func (h *hedger) do(c *Client, req *http.Request, next func(*Client, *http.Request) (*http.Response, error)) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	ctx := req.Context()
+	results := make(chan hedgeResult, 2)
+
+	var cancels []context.CancelFunc
+
+	send := func(body []byte, hedge bool) {
+		attemptCtx, cancel := context.WithCancel(ctx)
+		cancels = append(cancels, cancel)
+
+		attempt := req.Clone(attemptCtx)
+		attempt.Body = io.NopCloser(bytes.NewReader(body))
+		attempt.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		attempt.ContentLength = int64(len(body))
+
+		go func(n int) {
+			start := time.Now()
+			resp, err := next(c, attempt)
+			if hedge {
+				h.inFlight.Add(-1)
+			}
+			results <- hedgeResult{attempt: n, resp: resp, err: err, latency: time.Since(start)}
+		}(len(cancels) - 1)
+	}
+
+	send(body, false)
+	pending := 1
+
+	timer := time.NewTimer(h.delay())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if h.inFlight.Add(1) > int32(h.policy.MaxInFlight) {
+				h.inFlight.Add(-1)
+				continue
+			}
+
+			send(h.hedgeBody(body), true)
+			pending++
+
+		case res := <-results:
+			pending--
+
+			if !hedgeWon(res) && pending > 0 {
+				discard(res, cancels[res.attempt])
+				continue
+			}
+
+			// cancel the loser right away, its result is released in the
+			// background
+			for i, cancel := range cancels {
+				if i != res.attempt {
+					cancel()
+				}
+			}
+			if pending > 0 {
+				go drain(results, pending)
+			}
+
+			if res.err != nil {
+				cancels[res.attempt]()
+				return nil, res.err
+			}
+
+			h.record(res.latency)
+			res.resp.Body = &cancelOnClose{ReadCloser: res.resp.Body, cancel: cancels[res.attempt]}
+
+			return res.resp, nil
+		}
+	}
+}
+
+// hedgeBody returns the body of the hedged request, with the model replaced
+// by AlternateModel when set. OpenRouter fallbacks are dropped so the hedge
+// really goes to the alternate model. Bodies that are not JSON objects are
+// sent unchanged.
+func (h *hedger) hedgeBody(body []byte) []byte {
+	if h.policy.AlternateModel == "" {
+		return body
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return body
+	}
+
+	model, _ := json.Marshal(h.policy.AlternateModel)
+	fields["model"] = model
+	delete(fields, "models")
+
+	hedged, err := json.Marshal(fields)
+	if err != nil {
+		return body
+	}
+
+	return hedged
+}
+
+// delay returns the configured percentile of the recent latencies.
+func (h *hedger) delay() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.latencies) < minHedgeSamples {
+		return h.policy.InitialDelay
+	}
+
+	sorted := slices.Clone(h.latencies)
+	slices.Sort(sorted)
+
+	// nearest rank
+	rank := int(math.Ceil(float64(len(sorted))*h.policy.Percentile/100)) - 1
+	return sorted[max(0, min(rank, len(sorted)-1))]
+}
+
+func (h *hedger) record(latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.latencies) < h.policy.Window {
+		h.latencies = append(h.latencies, latency)
+		return
+	}
+
+	h.latencies[h.pos] = latency
+	h.pos = (h.pos + 1) % h.policy.Window
+}
+
+func hedgeWon(res hedgeResult) bool {
+	return res.err == nil && res.resp.StatusCode < http.StatusInternalServerError
+}
+
+// drain releases the requests that lost the race, already cancelled.
+func drain(results <-chan hedgeResult, pending int) {
+	for range pending {
+		if res := <-results; res.resp != nil {
+			res.resp.Body.Close()
+		}
+	}
+}
+
+func discard(res hedgeResult, cancel context.CancelFunc) {
+	cancel()
+	if res.resp != nil {
+		res.resp.Body.Close()
+	}
+}
@@ -0,0 +1,165 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithHedging(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        HedgePolicy
+		primaryDelay  time.Duration
+		wantRequests  int32
+		wantHedge     string
+		wantCancelled bool
+	}{
+		{
+			name:         "fast primary is not hedged",
+			policy:       HedgePolicy{InitialDelay: 200 * time.Millisecond},
+			wantRequests: 1,
+		},
+		{
+			name:          "slow primary loses to the hedge",
+			policy:        HedgePolicy{InitialDelay: 20 * time.Millisecond},
+			primaryDelay:  5 * time.Second,
+			wantRequests:  2,
+			wantHedge:     "primary",
+			wantCancelled: true,
+		},
+		{
+			name:          "hedge goes to the alternate model",
+			policy:        HedgePolicy{InitialDelay: 20 * time.Millisecond, AlternateModel: "alternate"},
+			primaryDelay:  5 * time.Second,
+			wantRequests:  2,
+			wantHedge:     "alternate",
+			wantCancelled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				requests  atomic.Int32
+				cancelled = make(chan struct{})
+				mu        sync.Mutex
+				hedge     OpenRouterRequest
+			)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req OpenRouterRequest
+				json.NewDecoder(r.Body).Decode(&req)
+
+				if requests.Add(1) == 1 {
+					select {
+					case <-time.After(tt.primaryDelay):
+					case <-r.Context().Done():
+						close(cancelled)
+						return
+					}
+				} else {
+					mu.Lock()
+					hedge = req
+					mu.Unlock()
+				}
+
+				writeContent(w, `{"service_id": 12, "service_name": "Consulta do Saldo"}`)
+			}))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, WithModels("primary", "backup"), WithHedging(tt.policy))
+
+			start := time.Now()
+
+			if _, err := client.ChatCompletion(context.Background(), "qual meu saldo"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("expected a fast answer, took %v", elapsed)
+			}
+
+			if tt.wantCancelled {
+				select {
+				case <-cancelled:
+				case <-time.After(time.Second):
+					t.Error("expected the losing request to be cancelled")
+				}
+			}
+
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("expected %d requests, got %d", tt.wantRequests, got)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if hedge.Model != tt.wantHedge {
+				t.Errorf("expected the hedge to use model %q, got %q", tt.wantHedge, hedge.Model)
+			}
+			if tt.wantHedge == "alternate" && len(hedge.Models) > 0 {
+				t.Errorf("expected no fallback models on the alternate hedge, got %v", hedge.Models)
+			}
+		})
+	}
+}
+
+func TestWithHedging_MaxInFlight(t *testing.T) {
+	var requests atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(150 * time.Millisecond)
+		writeContent(w, `{"service_id": 12, "service_name": "Consulta do Saldo"}`)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, WithHedging(HedgePolicy{InitialDelay: 20 * time.Millisecond, MaxInFlight: 1}))
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.ChatCompletion(context.Background(), "qual meu saldo"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// three calls plus the single hedge allowed
+	if got := requests.Load(); got != 4 {
+		t.Errorf("expected 4 requests, got %d", got)
+	}
+}
+
+func TestHedgerDelay(t *testing.T) {
+	h := &hedger{policy: HedgePolicy{Percentile: 90, InitialDelay: time.Second, Window: 10}}
+
+	for i := 1; i < minHedgeSamples; i++ {
+		h.record(time.Duration(i) * time.Millisecond)
+	}
+	if got := h.delay(); got != time.Second {
+		t.Errorf("expected the initial delay before %d samples, got %v", minHedgeSamples, got)
+	}
+
+	h.record(10 * time.Millisecond)
+	if got := h.delay(); got != 9*time.Millisecond {
+		t.Errorf("expected p90 of 1..10ms to be 9ms, got %v", got)
+	}
+
+	// the window keeps only the most recent latencies
+	for range 10 {
+		h.record(100 * time.Millisecond)
+	}
+	if got := h.delay(); got != 100*time.Millisecond {
+		t.Errorf("expected old latencies to be evicted, got %v", got)
+	}
+}
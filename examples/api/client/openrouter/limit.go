@@ -0,0 +1,263 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrLimitExceeded is returned when a request is rejected by the client-side
+// rate or concurrency limit instead of waiting: the queue is full or the
+// wait would outlast the request deadline.
+var ErrLimitExceeded = errors.New("client-side limit exceeded")
+
+// RateLimit configures WithRateLimit.
+type RateLimit struct {
+	// Rate is the sustained number of requests per second. Zero, like any
+	// rate below it, disables the limit: every request goes out at once.
+	Rate float64
+	// Burst is how many requests may go out at once after an idle period,
+	// at least 1.
+	Burst int
+	// Stats, when set, collects the queue time and rejections.
+	Stats *LimiterStats
+}
+
+// ConcurrencyLimit configures WithConcurrencyLimit.
+type ConcurrencyLimit struct {
+	// Max is the number of requests in flight at the same time.
+	Max int
+	// MaxQueue rejects requests once this many are waiting; zero queues
+	// without bound.
+	MaxQueue int
+	// Stats, when set, collects the queue time and rejections.
+	Stats *LimiterStats
+}
+
+// LimiterStats counts what a limiter did. It is safe for concurrent use; read
+// it with Snapshot.
+type LimiterStats struct {
+	admitted  atomic.Int64
+	rejected  atomic.Int64
+	waiting   atomic.Int64
+	totalWait atomic.Int64
+	maxWait   atomic.Int64
+}
+
+// LimiterSnapshot is a point-in-time copy of LimiterStats.
+type LimiterSnapshot struct {
+	Admitted int64
+	// Rejected counts the requests that never got through: rejected with
+	// ErrLimitExceeded or cancelled while queued.
+	Rejected int64
+	// Waiting is the number of requests queued right now.
+	Waiting int64
+	// TotalWait and MaxWait are the queue times of the admitted requests.
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// Snapshot returns the current counters.
+func (s *LimiterStats) Snapshot() LimiterSnapshot {
+	return LimiterSnapshot{
+		Admitted:  s.admitted.Load(),
+		Rejected:  s.rejected.Load(),
+		Waiting:   s.waiting.Load(),
+		TotalWait: time.Duration(s.totalWait.Load()),
+		MaxWait:   time.Duration(s.maxWait.Load()),
+	}
+}
+
+// AverageWait is the mean queue time of the admitted requests.
+func (s LimiterSnapshot) AverageWait() time.Duration {
+	if s.Admitted == 0 {
+		return 0
+	}
+
+	return s.TotalWait / time.Duration(s.Admitted)
+}
+
+func (s *LimiterStats) queued() {
+	if s != nil {
+		s.waiting.Add(1)
+	}
+}
+
+func (s *LimiterStats) done(wait time.Duration, admitted bool) {
+	if s == nil {
+		return
+	}
+
+	s.waiting.Add(-1)
+	if !admitted {
+		s.rejected.Add(1)
+		return
+	}
+
+	s.admitted.Add(1)
+	s.totalWait.Add(int64(wait))
+	for {
+		current := s.maxWait.Load()
+		if int64(wait) <= current || s.maxWait.CompareAndSwap(current, int64(wait)) {
+			return
+		}
+	}
+}
+
+// WithRateLimit spaces requests out with a token bucket so bursts do not
+// turn into 429s. Requests wait for a token while their context allows it
+// and fail with ErrLimitExceeded when the wait would outlast the deadline.
+func WithRateLimit(limit RateLimit) Option {
+	b := &tokenBucket{
+		rate:   limit.Rate,
+		burst:  float64(max(limit.Burst, 1)),
+		tokens: float64(max(limit.Burst, 1)),
+		last:   time.Now(),
+	}
+
+	return func(c *Client) {
+		next := c.doFunc
+		c.doFunc = func(c *Client, req *http.Request) (*http.Response, error) {
+			if err := b.wait(req.Context(), limit.Stats); err != nil {
+				return nil, err
+			}
+
+			return next(c, req)
+		}
+	}
+}
+
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// reserve takes a token, possibly going into debt, and returns how long the
+// caller has to wait for it.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 || b.rate <= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel gives back a token the caller will not use.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.burst, b.tokens+1)
+}
+
+func (b *tokenBucket) wait(ctx context.Context, stats *LimiterStats) error {
+	stats.queued()
+
+	delay := b.reserve(time.Now())
+	if delay == 0 {
+		stats.done(0, true)
+		return nil
+	}
+
+	if !fitsDeadline(ctx, delay) {
+		b.cancel()
+		stats.done(0, false)
+		return ErrLimitExceeded
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		stats.done(delay, true)
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		stats.done(0, false)
+		return ctx.Err()
+	}
+}
+
+// WithConcurrencyLimit caps the requests in flight. A slot is held until the
+// response body is closed. Requests queue for a slot while their context
+// allows it, and fail with ErrLimitExceeded when MaxQueue are already
+// waiting.
+func WithConcurrencyLimit(limit ConcurrencyLimit) Option {
+	sem := &semaphore{slots: make(chan struct{}, max(limit.Max, 1)), maxQueue: limit.MaxQueue}
+
+	return func(c *Client) {
+		next := c.doFunc
+		c.doFunc = func(c *Client, req *http.Request) (*http.Response, error) {
+			release, err := sem.acquire(req.Context(), limit.Stats)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := next(c, req)
+			if err != nil {
+				release()
+				return nil, err
+			}
+
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: context.CancelFunc(release)}
+
+			return resp, nil
+		}
+	}
+}
+
+type semaphore struct {
+	slots    chan struct{}
+	maxQueue int
+	waiting  atomic.Int64
+}
+
+// acquire waits for a slot and returns the function releasing it, safe to
+// call more than once.
+func (s *semaphore) acquire(ctx context.Context, stats *LimiterStats) (func(), error) {
+	var once sync.Once
+	release := func() { once.Do(func() { <-s.slots }) }
+
+	stats.queued()
+
+	// fast path, a free slot
+	select {
+	case s.slots <- struct{}{}:
+		stats.done(0, true)
+		return release, nil
+	default:
+	}
+
+	if waiting := s.waiting.Add(1); s.maxQueue > 0 && waiting > int64(s.maxQueue) {
+		s.waiting.Add(-1)
+		stats.done(0, false)
+		return nil, ErrLimitExceeded
+	}
+	defer s.waiting.Add(-1)
+
+	start := time.Now()
+
+	select {
+	case s.slots <- struct{}{}:
+		stats.done(time.Since(start), true)
+		return release, nil
+	case <-ctx.Done():
+		stats.done(0, false)
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,167 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	var stats LimiterStats
+	client := NewClient(srv.URL, WithRateLimit(RateLimit{Rate: 20, Burst: 2, Stats: &stats}))
+
+	start := time.Now()
+
+	// two go out with the burst, the next two wait 50ms each
+	for range 4 {
+		resp, err := client.Do(context.Background(), newTestRequest(t, srv.URL))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected requests to be spaced out, took %v", elapsed)
+	}
+
+	// the wait does not fit the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.Do(ctx, newTestRequest(t, srv.URL)); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("expected ErrLimitExceeded, got %v", err)
+	}
+
+	snapshot := stats.Snapshot()
+	if snapshot.Admitted != 4 || snapshot.Rejected != 1 || snapshot.Waiting != 0 {
+		t.Errorf("unexpected stats: %+v", snapshot)
+	}
+	if snapshot.MaxWait < 40*time.Millisecond || snapshot.AverageWait() <= 0 {
+		t.Errorf("expected the queue time to be recorded, got %+v", snapshot)
+	}
+}
+
+func TestWithRateLimit_ZeroRate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	var stats LimiterStats
+	client := NewClient(srv.URL, WithRateLimit(RateLimit{Stats: &stats}))
+
+	for range 5 {
+		resp, err := client.Do(context.Background(), newTestRequest(t, srv.URL))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if snapshot := stats.Snapshot(); snapshot.Admitted != 5 || snapshot.MaxWait != 0 {
+		t.Errorf("expected no request to wait, got %+v", snapshot)
+	}
+}
+
+func TestWithConcurrencyLimit(t *testing.T) {
+	var (
+		inFlight atomic.Int32
+		peak     atomic.Int32
+	)
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			current := peak.Load()
+			if n <= current || peak.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		<-release
+	}))
+	defer srv.Close()
+
+	var stats LimiterStats
+	client := NewClient(srv.URL, WithConcurrencyLimit(ConcurrencyLimit{Max: 2, MaxQueue: 2, Stats: &stats}))
+
+	var (
+		wg       sync.WaitGroup
+		rejected atomic.Int32
+	)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			resp, err := client.Do(context.Background(), newTestRequest(t, srv.URL))
+			if errors.Is(err, ErrLimitExceeded) {
+				rejected.Add(1)
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+
+	// two in flight, two queued, one rejected
+	time.Sleep(100 * time.Millisecond)
+	if got := stats.Snapshot().Waiting; got != 2 {
+		t.Errorf("expected 2 waiting, got %d", got)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if got := peak.Load(); got != 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", got)
+	}
+	if got := rejected.Load(); got != 1 {
+		t.Errorf("expected 1 rejection, got %d", got)
+	}
+
+	snapshot := stats.Snapshot()
+	if snapshot.Admitted != 4 || snapshot.Rejected != 1 || snapshot.Waiting != 0 {
+		t.Errorf("unexpected stats: %+v", snapshot)
+	}
+}
+
+func TestWithConcurrencyLimit_ContextCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, WithConcurrencyLimit(ConcurrencyLimit{Max: 1}))
+
+	// hold the only slot by not closing the body
+	resp, err := client.Do(context.Background(), newTestRequest(t, srv.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.Do(ctx, newTestRequest(t, srv.URL)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded while queued, got %v", err)
+	}
+
+	resp.Body.Close()
+
+	resp, err = client.Do(context.Background(), newTestRequest(t, srv.URL))
+	if err != nil {
+		t.Fatalf("expected the slot to be released, got %v", err)
+	}
+	resp.Body.Close()
+}
@@ -2,6 +2,7 @@ package openrouter
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"time"
 )
@@ -12,16 +13,38 @@ type Client struct {
 	baseURL string
 	client  *http.Client
 	doFunc  func(c *Client, req *http.Request) (*http.Response, error)
+	timeout time.Duration
+	catalog Catalog
+
+	outputMode  OutputMode
+	pricing     map[string]Pricing
+	topLogprobs int
+
+	models          []string
+	provider        *ProviderPreferences
+	fallbackModels  []string
+	fallbackTimeout time.Duration
+
+	embeddingBatchSize int
 }
 
+// placeholderModel stands for the model until WithModels sets a real one.
+const placeholderModel = "<definir_modelo>"
+
 func NewClient(baseURL string, opts ...Option) *Client {
 	c := &Client{
-		baseURL: baseURL,
+		baseURL:            baseURL,
+		timeout:            DefaultTimeoutSecs * time.Second,
+		catalog:            DefaultCatalog,
+		models:             []string{placeholderModel},
+		embeddingBatchSize: DefaultEmbeddingBatchSize,
 		client: &http.Client{
 			Transport: NewTransport(),
 		},
 		doFunc: func(c *Client, req *http.Request) (*http.Response, error) {
-			req.Header.Set("Accept", "application/json")
+			if req.Header.Get("Accept") == "" {
+				req.Header.Set("Accept", "application/json")
+			}
 			return c.client.Do(req)
 		},
 	}
@@ -33,15 +56,39 @@ func NewClient(baseURL string, opts ...Option) *Client {
 	return c
 }
 
+// Do sends req through the option chain bound to ctx, so cancelling ctx
+// aborts the call. The client timeout, if any, applies on top of ctx and
+// lasts until the response body is closed.
 func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
-	resp, err := c.doFunc(c, req)
+	cancel := context.CancelFunc(func() {})
+	if c.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+	}
+
+	resp, err := c.doFunc(c, req.WithContext(ctx))
 	if err != nil {
+		cancel()
 		return nil, err
 	}
 
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
 	return resp, nil
 }
 
+// cancelOnClose releases the request context once the body is closed, so a
+// deadline keeps covering the body read but does not leak afterwards.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
 // NewTransport initializes a new http.Transport.
 func NewTransport() *http.Transport {
 	return &http.Transport{
@@ -0,0 +1,65 @@
+package openrouter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDo_HonoursContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		timeout time.Duration
+	}{
+		{name: "caller deadline", timeout: 50 * time.Millisecond},
+		{name: "client timeout", opts: []Option{WithTimeout(50 * time.Millisecond)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			client := NewClient(srv.URL, tt.opts...)
+
+			start := time.Now()
+
+			_, err := client.Do(ctx, newTestRequest(t, srv.URL))
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected context.DeadlineExceeded, got %v", err)
+			}
+
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("expected the request to be aborted, took %v", elapsed)
+			}
+		})
+	}
+}
+
+func newTestRequest(t *testing.T, baseURL string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/chat/completions", bytes.NewBufferString(`{"intent":"segunda via"}`))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	return req
+}
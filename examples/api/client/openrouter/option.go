@@ -2,6 +2,7 @@ package openrouter
 
 import (
 	"net/http"
+	"time"
 )
 
 type Option func(*Client)
@@ -15,3 +16,28 @@ func WithAuth(token string) Option {
 		}
 	}
 }
+
+// WithTimeout sets the deadline applied to every request, replacing the
+// DefaultTimeoutSecs default. A context with an earlier deadline still wins.
+// Zero disables the client timeout, leaving only the caller's context.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithCatalog replaces DefaultCatalog as the source of the JSON schema sent
+// in response_format. An empty catalog sends no response_format at all.
+func WithCatalog(catalog Catalog) Option {
+	return func(c *Client) {
+		c.catalog = catalog
+	}
+}
+
+// WithOutputMode selects how the model is constrained, OutputJSONSchema by
+// default.
+func WithOutputMode(mode OutputMode) Option {
+	return func(c *Client) {
+		c.outputMode = mode
+	}
+}
@@ -0,0 +1,170 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultRetryAttempts  = 3
+	DefaultRetryBaseDelay = 200 * time.Millisecond
+	DefaultRetryMaxDelay  = 5 * time.Second
+)
+
+// RetryPolicy configures WithRetry. Zero values fall back to the defaults.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles on every
+	// further attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps a single wait. A Retry-After asking for longer than
+	// MaxDelay ends the retries instead.
+	MaxDelay time.Duration
+}
+
+// WithRetry retries requests that failed with a network error, 429 or a 5xx
+// status using exponential backoff with jitter. The Retry-After header is
+// honoured, and no retry is attempted when its wait would overrun the
+// request deadline; in that case the last response or error is returned.
+// Options applied after WithRetry wrap every attempt, the ones applied
+// before it (such as WithAuth) run once per attempt as well.
+func WithRetry(policy RetryPolicy) Option {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = DefaultRetryAttempts
+	}
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = DefaultRetryBaseDelay
+	}
+	if policy.MaxDelay <= 0 {
+		policy.MaxDelay = DefaultRetryMaxDelay
+	}
+
+	return func(c *Client) {
+		next := c.doFunc
+		c.doFunc = func(c *Client, req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+
+			for attempt := 1; ; attempt++ {
+				attemptReq, err := rewindRequest(req, attempt)
+				if err != nil {
+					return nil, err
+				}
+
+				resp, err := next(c, attemptReq)
+				if attempt >= policy.MaxAttempts || !retryable(ctx, req, resp, err) {
+					return resp, err
+				}
+
+				delay, ok := policy.delay(attempt, resp)
+				if !ok || !fitsDeadline(ctx, delay) {
+					return resp, err
+				}
+
+				if resp != nil {
+					io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}
+
+				timer := time.NewTimer(delay)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return nil, ctx.Err()
+				case <-timer.C:
+				}
+			}
+		}
+	}
+}
+
+// rewindRequest returns the request to send on the given attempt. Retries
+// need a fresh copy of the body, which http.NewRequest provides through
+// GetBody for in-memory bodies.
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = body
+
+	return clone, nil
+}
+
+func retryable(ctx context.Context, req *http.Request, resp *http.Response, err error) bool {
+	// a body that cannot be replayed cannot be retried
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		// network error, unless it was caused by the caller giving up or
+		// the breaker refused the call
+		return ctx.Err() == nil && !errors.Is(err, ErrCircuitOpen)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// delay returns how long to wait before the next attempt. It reports false
+// when the server asked to wait longer than the policy allows.
+func (p RetryPolicy) delay(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait, wait <= p.MaxDelay
+		}
+	}
+
+	backoff := p.BaseDelay << (attempt - 1)
+	if backoff <= 0 || backoff > p.MaxDelay {
+		backoff = p.MaxDelay
+	}
+
+	// equal jitter: half fixed, half random, so retries from concurrent
+	// callers spread out without collapsing to zero
+	half := backoff / 2
+	return half + rand.N(half+1), true
+}
+
+// parseRetryAfter accepts both forms allowed by RFC 9110: a number of
+// seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+func fitsDeadline(ctx context.Context, delay time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > delay
+}
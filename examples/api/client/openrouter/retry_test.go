@@ -0,0 +1,192 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       RetryPolicy
+		responses    []int
+		retryAfter   string
+		wantStatus   int
+		wantAttempts int32
+	}{
+		{
+			name:         "succeeds after transient errors",
+			policy:       RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			responses:    []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 3,
+		},
+		{
+			name:         "retries rate limiting",
+			policy:       RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+			responses:    []int{http.StatusTooManyRequests, http.StatusOK},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:         "returns last response when attempts run out",
+			policy:       RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+			responses:    []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK},
+			wantStatus:   http.StatusInternalServerError,
+			wantAttempts: 2,
+		},
+		{
+			name:         "does not retry client errors",
+			policy:       RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			responses:    []int{http.StatusBadRequest, http.StatusOK},
+			wantStatus:   http.StatusBadRequest,
+			wantAttempts: 1,
+		},
+		{
+			name: "honours Retry-After over the backoff",
+			// a backoff this long would time the test out
+			policy:       RetryPolicy{MaxAttempts: 2, BaseDelay: time.Minute, MaxDelay: time.Minute},
+			responses:    []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:   "0",
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:         "gives up when Retry-After exceeds the max delay",
+			policy:       RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second},
+			responses:    []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:   "120",
+			wantStatus:   http.StatusTooManyRequests,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != `{"intent":"segunda via"}` {
+					t.Errorf("attempt %d: expected the body to be replayed, got %q", n, body)
+				}
+				if r.Header.Get("Authorization") != "Bearer test-token" {
+					t.Errorf("attempt %d: expected the auth header on every attempt", n)
+				}
+
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.responses[n-1])
+			}))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, WithAuth("test-token"), WithRetry(tt.policy))
+
+			resp, err := client.Do(context.Background(), newTestRequest(t, srv.URL))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.wantAttempts, got)
+			}
+		})
+	}
+}
+
+func TestWithRetry_NetworkError(t *testing.T) {
+	var attempts atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			// drop the connection without answering
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+
+	resp, err := client.Do(context.Background(), newTestRequest(t, srv.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestWithRetry_StaysWithinDeadline(t *testing.T) {
+	var attempts atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, WithRetry(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Second}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	resp, err := client.Do(ctx, newTestRequest(t, srv.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("expected to give up without waiting, took %v", elapsed)
+	}
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the last response to be returned, got %d", resp.StatusCode)
+	}
+
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestWithRetry_ContextCanceledWhileWaiting(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, WithRetry(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Second}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := client.Do(ctx, newTestRequest(t, srv.URL))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
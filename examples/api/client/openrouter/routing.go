@@ -0,0 +1,71 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Provider sort strategies understood by OpenRouter.
+const (
+	SortPrice      = "price"
+	SortLatency    = "latency"
+	SortThroughput = "throughput"
+)
+
+// ErrInvalidOutput is returned when the model answered but the answer could
+// not be read as a classification.
+var ErrInvalidOutput = errors.New("invalid model output")
+
+// ProviderPreferences controls how OpenRouter picks the provider serving the
+// model. See https://openrouter.ai/docs/features/provider-routing.
+type ProviderPreferences struct {
+	// Order lists provider slugs to try first, e.g. "groq" or "openai".
+	Order []string `json:"order,omitempty"`
+	// Sort is one of SortPrice, SortLatency or SortThroughput.
+	Sort string `json:"sort,omitempty"`
+	// AllowFallbacks set to false restricts routing to the Order providers.
+	AllowFallbacks *bool `json:"allow_fallbacks,omitempty"`
+}
+
+// WithModels sets the model to request, replacing the placeholder, followed
+// by the models OpenRouter falls back to when it fails to serve the first.
+func WithModels(models ...string) Option {
+	return func(c *Client) {
+		if len(models) > 0 {
+			c.models = models
+		}
+	}
+}
+
+// WithProvider sets the provider routing preferences sent on every request.
+func WithProvider(prefs ProviderPreferences) Option {
+	return func(c *Client) {
+		c.provider = &prefs
+	}
+}
+
+// WithFallback makes the client itself retry with the next model when a
+// model does not answer within timeout or its answer cannot be parsed.
+// Unlike the OpenRouter fallbacks of WithModels, this also covers slow
+// models and invalid output. The last model runs until the request
+// deadline. A zero timeout does not cut attempts short, but an attempt
+// that runs into the client timeout of WithTimeout still falls back; only
+// WithTimeout(0) limits fallback to invalid output.
+func WithFallback(timeout time.Duration, models ...string) Option {
+	return func(c *Client) {
+		c.fallbackTimeout = timeout
+		c.fallbackModels = models
+	}
+}
+
+// shouldFallback reports whether err warrants trying the next model: the
+// attempt timed out while the caller is still waiting, or the output was
+// invalid.
+func shouldFallback(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrInvalidOutput)
+}
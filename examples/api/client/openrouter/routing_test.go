@@ -0,0 +1,156 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithModelsAndProvider(t *testing.T) {
+	var got OpenRouterRequest
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		writeContent(w, `{"service_id": 12, "service_name": "Consulta do Saldo"}`)
+	}))
+	defer srv.Close()
+
+	allowFallbacks := false
+	client := NewClient(srv.URL,
+		WithModels("openai/gpt-4o-mini", "google/gemini-2.0-flash-001"),
+		WithProvider(ProviderPreferences{Order: []string{"openai"}, Sort: SortLatency, AllowFallbacks: &allowFallbacks}),
+	)
+
+	if _, err := client.ChatCompletion(context.Background(), "qual meu saldo"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Model != "openai/gpt-4o-mini" {
+		t.Errorf("expected model openai/gpt-4o-mini, got %s", got.Model)
+	}
+	if want := []string{"openai/gpt-4o-mini", "google/gemini-2.0-flash-001"}; !reflect.DeepEqual(got.Models, want) {
+		t.Errorf("expected models %v, got %v", want, got.Models)
+	}
+	if got.Provider == nil || got.Provider.Sort != SortLatency || got.Provider.AllowFallbacks == nil || *got.Provider.AllowFallbacks {
+		t.Errorf("unexpected provider preferences: %+v", got.Provider)
+	}
+}
+
+func TestWithFallback(t *testing.T) {
+	const valid = `{"service_id": 12, "service_name": "Consulta do Saldo"}`
+
+	tests := []struct {
+		name       string
+		behaviour  map[string]string
+		ctxTimeout time.Duration
+		wantModels []string
+		wantErr    bool
+		wantErrIs  error
+	}{
+		{
+			name:       "first model answers",
+			behaviour:  map[string]string{"primary": valid},
+			wantModels: []string{"primary"},
+		},
+		{
+			name:       "timeout moves to the next model",
+			behaviour:  map[string]string{"primary": "slow", "secondary": valid},
+			wantModels: []string{"primary", "secondary"},
+		},
+		{
+			name:       "invalid output moves to the next model",
+			behaviour:  map[string]string{"primary": "não sei", "secondary": "slow", "tertiary": valid},
+			wantModels: []string{"primary", "secondary", "tertiary"},
+		},
+		{
+			name:       "API errors do not fall back",
+			behaviour:  map[string]string{"primary": "error"},
+			wantModels: []string{"primary"},
+			wantErr:    true,
+		},
+		{
+			name:       "last model failure is returned",
+			behaviour:  map[string]string{"primary": "não sei", "secondary": "não sei", "tertiary": "?"},
+			wantModels: []string{"primary", "secondary", "tertiary"},
+			wantErr:    true,
+			wantErrIs:  ErrInvalidOutput,
+		},
+		{
+			name:       "caller deadline stops the chain",
+			behaviour:  map[string]string{"primary": "slow"},
+			ctxTimeout: 20 * time.Millisecond,
+			wantModels: []string{"primary"},
+			wantErr:    true,
+			wantErrIs:  context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu     sync.Mutex
+				models []string
+			)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req OpenRouterRequest
+				json.NewDecoder(r.Body).Decode(&req)
+
+				mu.Lock()
+				models = append(models, req.Model)
+				mu.Unlock()
+
+				switch content := tt.behaviour[req.Model]; content {
+				case "slow":
+					<-r.Context().Done()
+				case "error":
+					w.WriteHeader(http.StatusBadRequest)
+				default:
+					writeContent(w, content)
+				}
+			}))
+			defer srv.Close()
+
+			client := NewClient(srv.URL,
+				WithModels("primary"),
+				WithFallback(50*time.Millisecond, "secondary", "tertiary"),
+			)
+
+			ctx := context.Background()
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+
+			_, err := client.ChatCompletion(ctx, "qual meu saldo")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("expected %v, got %v", tt.wantErrIs, err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if !reflect.DeepEqual(models, tt.wantModels) {
+				t.Errorf("expected models %v to be tried, got %v", tt.wantModels, models)
+			}
+		})
+	}
+}
+
+func writeContent(w http.ResponseWriter, content string) {
+	json.NewEncoder(w).Encode(map[string]any{
+		"choices": []any{map[string]any{"message": map[string]any{"content": content}}},
+	})
+}
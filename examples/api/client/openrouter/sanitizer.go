@@ -0,0 +1,39 @@
+package openrouter
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+var codeFenceRe = regexp.MustCompile("(?s)```(?:json)?\\s*(.*?)\\s*```")
+
+// extractJSON pulls the first JSON object out of a model answer, for models
+// that ignore response_format and wrap the JSON in prose or code fences.
+// When no object is found the trimmed input is returned unchanged so the
+// caller reports the original content.
+func extractJSON(s string) string {
+	s = strings.TrimSpace(s)
+
+	// 1) prefer the contents of a fenced block
+	if m := codeFenceRe.FindStringSubmatch(s); len(m) == 2 {
+		s = m[1]
+	}
+
+	// 2) first complete object in the text; decoding from every "{" skips
+	// braces that belong to prose and ignores anything after the object
+	for i := strings.IndexByte(s, '{'); i >= 0; {
+		var obj json.RawMessage
+		if err := json.NewDecoder(strings.NewReader(s[i:])).Decode(&obj); err == nil && obj[0] == '{' {
+			return string(obj)
+		}
+
+		next := strings.IndexByte(s[i+1:], '{')
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+
+	return s
+}
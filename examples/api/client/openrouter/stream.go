@@ -0,0 +1,160 @@
+package openrouter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	serviceIDFieldRe = regexp.MustCompile(`"service_id"\s*:\s*"?(\d+)`)
+	bareServiceIDRe  = regexp.MustCompile(`^\s*(\d+)`)
+)
+
+// streamChunk is one "data:" event of a streamed chat completion.
+type streamChunk struct {
+	Choices []struct {
+		Delta Message `json:"delta"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ChatCompletionStream classifies intent like ChatCompletion but streams the
+// answer, returning as soon as the service_id is known and cancelling the
+// rest of the stream. For short answers such as "7" or {"service_id": 7
+// this skips waiting for the model to finish. Early exit needs a catalog,
+// which provides the service name; without one the whole answer is read.
+func (c *Client) ChatCompletionStream(ctx context.Context, intent string) (*DataResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req, err := c.newChatRequest(ctx, c.models, intent, true)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	// content in OutputJSONSchema mode, the route_call arguments in
+	// OutputToolCall mode
+	var answer strings.Builder
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		// lines starting with ":" are keep-alive comments
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk streamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return nil, fmt.Errorf("error unmarshaling stream chunk: %v. data: %s", err, data)
+		}
+
+		if chunk.Error != nil {
+			return nil, fmt.Errorf("stream failed: %s", chunk.Error.Message)
+		}
+
+		for _, choice := range chunk.Choices {
+			answer.WriteString(choice.Delta.Content)
+			for _, call := range choice.Delta.ToolCalls {
+				answer.WriteString(call.Function.Arguments)
+			}
+		}
+
+		if data, ok := c.streamedService(answer.String(), false); ok {
+			return data, nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading stream: %w", err)
+	}
+
+	if data, ok := c.streamedService(answer.String(), true); ok {
+		return data, nil
+	}
+
+	message := Message{Content: answer.String()}
+	if c.outputMode == OutputToolCall {
+		message = Message{ToolCalls: []ToolCall{{Function: ToolCallFunction{Name: RouteCallToolName, Arguments: answer.String()}}}}
+	}
+
+	data, err := c.parseMessage(message)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOutput, err)
+	}
+
+	return data, nil
+}
+
+// streamedService looks for a service_id in the partial answer, either as a
+// JSON field or as a bare number. The ID counts as complete once a non digit
+// follows it, the stream is done, or no catalog ID starts with its digits
+// (so "7" is final but "1" may still become "16").
+func (c *Client) streamedService(answer string, done bool) (*DataResponse, bool) {
+	if len(c.catalog) == 0 {
+		return nil, false
+	}
+
+	m := serviceIDFieldRe.FindStringSubmatchIndex(answer)
+	if m == nil {
+		m = bareServiceIDRe.FindStringSubmatchIndex(answer)
+	}
+	if m == nil {
+		return nil, false
+	}
+
+	digits := answer[m[2]:m[3]]
+	if !done && m[3] == len(answer) && c.catalog.hasLongerID(digits) {
+		return nil, false
+	}
+
+	id, err := strconv.ParseUint(digits, 10, 8)
+	if err != nil {
+		return nil, false
+	}
+
+	service, ok := c.catalog.Lookup(uint8(id))
+	if !ok {
+		return nil, false
+	}
+
+	return &DataResponse{ServiceID: service.ID, ServiceName: service.Name}, true
+}
+
+// hasLongerID reports whether some ID of the catalog extends digits.
+func (c Catalog) hasLongerID(digits string) bool {
+	for _, s := range c {
+		id := strconv.Itoa(int(s.ID))
+		if len(id) > len(digits) && strings.HasPrefix(id, digits) {
+			return true
+		}
+	}
+
+	return false
+}
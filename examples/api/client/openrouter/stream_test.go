@@ -0,0 +1,133 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChatCompletionStream(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		chunks    []string
+		want      DataResponse
+		wantErr   bool
+		earlyExit bool
+	}{
+		{
+			name:      "bare number exits early",
+			chunks:    []string{"7"},
+			want:      DataResponse{ServiceID: 7, ServiceName: "Cancelamento de cartão"},
+			earlyExit: true,
+		},
+		{
+			name:      "JSON field exits early",
+			chunks:    []string{`{"service`, `_id": 1`, `3, "service_name": "Pag`},
+			want:      DataResponse{ServiceID: 13, ServiceName: "Pagamento de contas"},
+			earlyExit: true,
+		},
+		{
+			name:   "ambiguous prefix waits for the end",
+			chunks: []string{"1"},
+			want:   DataResponse{ServiceID: 1, ServiceName: "Consulta Limite / Vencimento do cartão / Melhor dia de compra"},
+		},
+		{
+			name:   "without catalog the whole answer is parsed",
+			opts:   []Option{WithCatalog(nil)},
+			chunks: []string{`{"service_id": 7, `, `"service_name": "Cancelamento de cartão"}`},
+			want:   DataResponse{ServiceID: 7, ServiceName: "Cancelamento de cartão"},
+		},
+		{
+			name:    "unknown service",
+			chunks:  []string{"42"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finished := make(chan bool, 1)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req OpenRouterRequest
+				json.NewDecoder(r.Body).Decode(&req)
+				if !req.Stream {
+					t.Error("expected stream to be requested")
+				}
+
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprint(w, ": OPENROUTER PROCESSING\n\n")
+
+				for _, chunk := range tt.chunks {
+					writeChunk(w, Message{Content: chunk})
+				}
+
+				// a slow tail the client should not wait for on early exit
+				select {
+				case <-time.After(500 * time.Millisecond):
+					fmt.Fprint(w, "data: [DONE]\n\n")
+					finished <- true
+				case <-r.Context().Done():
+					finished <- false
+				}
+			}))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, tt.opts...)
+
+			got, err := client.ChatCompletionStream(context.Background(), "cancelar cartão")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, *got)
+			}
+
+			if completed := <-finished; completed == tt.earlyExit {
+				t.Errorf("expected early exit: %v, stream completed: %v", tt.earlyExit, completed)
+			}
+		})
+	}
+}
+
+func TestChatCompletionStream_ToolCall(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		for _, args := range []string{`{"serv`, `ice_id":`, ` 11}`} {
+			writeChunk(w, Message{ToolCalls: []ToolCall{{Function: ToolCallFunction{Arguments: args}}}})
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, WithOutputMode(OutputToolCall))
+
+	got, err := client.ChatCompletionStream(context.Background(), "roubaram meu cartão")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := (DataResponse{ServiceID: 11, ServiceName: "Perda e roubo"}); !reflect.DeepEqual(*got, want) {
+		t.Errorf("expected %+v, got %+v", want, *got)
+	}
+}
+
+func writeChunk(w http.ResponseWriter, delta Message) {
+	chunk, _ := json.Marshal(map[string]any{"choices": []any{map[string]any{"delta": delta}}})
+	fmt.Fprintf(w, "data: %s\n\n", chunk)
+	w.(http.Flusher).Flush()
+}
@@ -0,0 +1,132 @@
+package openrouter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RouteCallToolName is the name of the tool the model calls in OutputToolCall
+// mode.
+const RouteCallToolName = "route_call"
+
+// OutputMode selects how the model is asked to answer.
+type OutputMode int
+
+const (
+	// OutputJSONSchema asks for a JSON message constrained by response_format.
+	OutputJSONSchema OutputMode = iota
+	// OutputToolCall forces a call to the route_call tool and reads its
+	// arguments instead of the message content.
+	OutputToolCall
+)
+
+type (
+	Tool struct {
+		Type     string       `json:"type"`
+		Function ToolFunction `json:"function"`
+	}
+
+	ToolFunction struct {
+		Name        string         `json:"name"`
+		Description string         `json:"description,omitempty"`
+		Parameters  map[string]any `json:"parameters,omitempty"`
+	}
+
+	// ToolChoice forces the model to call the named function.
+	ToolChoice struct {
+		Type     string             `json:"type"`
+		Function ToolChoiceFunction `json:"function"`
+	}
+
+	ToolChoiceFunction struct {
+		Name string `json:"name"`
+	}
+
+	ToolCall struct {
+		ID       string           `json:"id"`
+		Type     string           `json:"type"`
+		Function ToolCallFunction `json:"function"`
+	}
+
+	// ToolCallFunction carries the arguments as the JSON encoded string the
+	// API returns.
+	ToolCallFunction struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	}
+)
+
+// RouteCallTool returns the route_call tool, whose only parameter is the
+// service_id enum of the catalog. The service names are listed in the
+// parameter description so the model can tell the IDs apart.
+func (c Catalog) RouteCallTool() Tool {
+	ids := make([]int, 0, len(c))
+	lines := make([]string, 0, len(c))
+
+	for _, s := range c {
+		ids = append(ids, int(s.ID))
+		lines = append(lines, fmt.Sprintf("%d - %s", s.ID, s.Name))
+	}
+
+	return Tool{
+		Type: "function",
+		Function: ToolFunction{
+			Name:        RouteCallToolName,
+			Description: "Encaminha a intenção do cliente para o serviço correspondente.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"service_id": map[string]any{
+						"type":        "integer",
+						"enum":        ids,
+						"description": "ID do serviço:\n" + strings.Join(lines, "\n"),
+					},
+				},
+				"required":             []string{"service_id"},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+// parseRouteCall reads the service from the route_call tool call. The name
+// always comes from the catalog, so it cannot drift from the ID.
+func (c Catalog) parseRouteCall(calls []ToolCall) (*DataResponse, error) {
+	for _, call := range calls {
+		if call.Function.Name != RouteCallToolName {
+			continue
+		}
+
+		var args struct {
+			ServiceID uint8 `json:"service_id"`
+		}
+		if err := json.Unmarshal([]byte(call.Function.Arguments), &args); err != nil {
+			return nil, fmt.Errorf("error unmarshaling %s arguments: %v. arguments: %s", RouteCallToolName, err, call.Function.Arguments)
+		}
+
+		service, ok := c.Lookup(args.ServiceID)
+		if !ok {
+			return nil, fmt.Errorf("%s returned unknown service_id %d", RouteCallToolName, args.ServiceID)
+		}
+
+		return &DataResponse{ServiceID: service.ID, ServiceName: service.Name}, nil
+	}
+
+	return nil, fmt.Errorf("no %s tool call in response", RouteCallToolName)
+}
+
+// check reports whether a structured output answer names a catalog
+// service, with the name that goes with its ID. Route calls need no check
+// since their name is always looked up.
+func (c Catalog) check(data *DataResponse) error {
+	service, ok := c.Lookup(data.ServiceID)
+	if !ok {
+		return fmt.Errorf("unknown service_id %d", data.ServiceID)
+	}
+	if data.ServiceName != service.Name {
+		return fmt.Errorf("service_id %d is %q, got service_name %q", data.ServiceID, service.Name, data.ServiceName)
+	}
+
+	return nil
+}
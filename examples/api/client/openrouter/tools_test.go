@@ -0,0 +1,120 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestRouteCallTool(t *testing.T) {
+	raw, err := json.Marshal(Catalog{{ID: 3, Name: "Segunda via de Fatura"}, {ID: 12, Name: "Consulta do Saldo"}}.RouteCallTool())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var tool struct {
+		Function struct {
+			Name       string `json:"name"`
+			Parameters struct {
+				Properties struct {
+					ServiceID struct {
+						Enum []int `json:"enum"`
+					} `json:"service_id"`
+				} `json:"properties"`
+			} `json:"parameters"`
+		} `json:"function"`
+	}
+	if err := json.Unmarshal(raw, &tool); err != nil {
+		t.Fatalf("unexpected error: %v. tool: %s", err, raw)
+	}
+
+	if tool.Function.Name != RouteCallToolName {
+		t.Errorf("expected tool %s, got %s", RouteCallToolName, tool.Function.Name)
+	}
+
+	enum := tool.Function.Parameters.Properties.ServiceID.Enum
+	if len(enum) != 2 || enum[0] != 3 || enum[1] != 12 {
+		t.Errorf("expected service_id enum [3 12], got %v", enum)
+	}
+}
+
+func TestChatCompletion_ToolCall(t *testing.T) {
+	tests := []struct {
+		name      string
+		toolCalls []ToolCall
+		want      DataResponse
+		wantErr   bool
+	}{
+		{
+			name: "reads the tool call arguments",
+			toolCalls: []ToolCall{
+				{ID: "call_1", Type: "function", Function: ToolCallFunction{Name: RouteCallToolName, Arguments: `{"service_id": 11}`}},
+			},
+			want: DataResponse{ServiceID: 11, ServiceName: "Perda e roubo"},
+		},
+		{
+			name: "unknown service",
+			toolCalls: []ToolCall{
+				{ID: "call_1", Type: "function", Function: ToolCallFunction{Name: RouteCallToolName, Arguments: `{"service_id": 42}`}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "malformed arguments",
+			toolCalls: []ToolCall{
+				{ID: "call_1", Type: "function", Function: ToolCallFunction{Name: RouteCallToolName, Arguments: `{"service_id": "onze"}`}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no tool call",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req OpenRouterRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Errorf("failed to decode request: %v", err)
+				}
+
+				if req.ResponseFormat != nil {
+					t.Errorf("expected no response_format in tool mode, got %+v", req.ResponseFormat)
+				}
+				if len(req.Tools) != 1 || req.Tools[0].Function.Name != RouteCallToolName {
+					t.Errorf("expected the %s tool, got %+v", RouteCallToolName, req.Tools)
+				}
+				if req.ToolChoice == nil || req.ToolChoice.Function.Name != RouteCallToolName {
+					t.Errorf("expected tool_choice to force %s, got %+v", RouteCallToolName, req.ToolChoice)
+				}
+
+				json.NewEncoder(w).Encode(map[string]any{
+					"choices": []any{map[string]any{"message": Message{Role: "assistant", ToolCalls: tt.toolCalls}}},
+				})
+			}))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, WithOutputMode(OutputToolCall))
+
+			got, err := client.ChatCompletion(context.Background(), "roubaram meu cartão")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, *got)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package openrouter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// Pricing is the price of a model in USD per million tokens, as listed on
+// the OpenRouter model page.
+type Pricing struct {
+	Prompt     float64
+	Completion float64
+}
+
+// Observation describes one request sent through the client.
+type Observation struct {
+	ID       string
+	Model    string
+	Provider string
+	// Status is the HTTP status code, zero when no response was received.
+	Status  int
+	Latency time.Duration
+	Usage   Usage
+	Cost    float64
+	Err     error
+}
+
+// WithPricing sets the per model prices used to compute the cost of each
+// call. Models missing from prices fall back to the cost OpenRouter reports.
+func WithPricing(prices map[string]Pricing) Option {
+	return func(c *Client) {
+		c.pricing = prices
+	}
+}
+
+func (c *Client) cost(model string, usage Usage) float64 {
+	price, ok := c.pricing[model]
+	if !ok {
+		return usage.Cost
+	}
+
+	return (float64(usage.PromptTokens)*price.Prompt + float64(usage.CompletionTokens)*price.Completion) / 1e6
+}
+
+// WithObserver calls observe after every request with its latency, status,
+// token usage and cost, so callers can track the budget and export metrics.
+// The body is buffered to read the usage and handed back untouched; event
+// streams are passed through and observed without usage.
+// Applied after WithRetry it observes every call once, applied before it
+// observes every attempt.
+func WithObserver(observe func(Observation)) Option {
+	return func(c *Client) {
+		next := c.doFunc
+		c.doFunc = func(c *Client, req *http.Request) (*http.Response, error) {
+			start := time.Now()
+
+			resp, err := next(c, req)
+			if err != nil {
+				observe(Observation{Latency: time.Since(start), Err: err})
+				return nil, err
+			}
+
+			obs := Observation{Status: resp.StatusCode}
+
+			if !isEventStream(resp) {
+				body, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if err != nil {
+					obs.Latency, obs.Err = time.Since(start), err
+					observe(obs)
+					return nil, err
+				}
+
+				resp.Body = io.NopCloser(bytes.NewReader(body))
+				obs.decode(body)
+			}
+
+			obs.Latency = time.Since(start)
+			obs.Cost = c.cost(obs.Model, obs.Usage)
+
+			observe(obs)
+
+			return resp, nil
+		}
+	}
+}
+
+// decode fills the response metadata, ignoring bodies that are not a
+// completion such as error payloads.
+func (o *Observation) decode(body []byte) {
+	var meta struct {
+		ID       string `json:"id"`
+		Model    string `json:"model"`
+		Provider string `json:"provider"`
+		Usage    Usage  `json:"usage"`
+	}
+	if json.Unmarshal(body, &meta) != nil {
+		return
+	}
+
+	o.ID = meta.ID
+	o.Model = meta.Model
+	o.Provider = meta.Provider
+	o.Usage = meta.Usage
+}
+
+func isEventStream(resp *http.Response) bool {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return mediaType == "text/event-stream"
+}
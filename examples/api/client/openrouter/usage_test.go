@@ -0,0 +1,140 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const completionBody = `{
+	"id": "gen-123",
+	"model": "openai/gpt-4o-mini",
+	"provider": "OpenAI",
+	"choices": [{"message": {"role": "assistant", "content": "{\"service_id\": 12, \"service_name\": \"Consulta do Saldo\"}"}}],
+	"usage": {"prompt_tokens": 1000, "completion_tokens": 20, "total_tokens": 1020, "cost": 0.5}
+}`
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		wantCost float64
+	}{
+		{name: "reported cost", wantCost: 0.5},
+		{
+			name:     "computed cost",
+			opts:     []Option{WithPricing(map[string]Pricing{"openai/gpt-4o-mini": {Prompt: 0.15, Completion: 0.6}})},
+			wantCost: 0.000162,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req OpenRouterRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Errorf("failed to decode request: %v", err)
+				}
+				if req.Usage == nil || !req.Usage.Include {
+					t.Error("expected usage accounting to be requested")
+				}
+
+				io.WriteString(w, completionBody)
+			}))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, tt.opts...)
+
+			result, err := client.Complete(context.Background(), "qual meu saldo")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Data.ServiceID != 12 {
+				t.Errorf("expected service 12, got %d", result.Data.ServiceID)
+			}
+			if result.ID != "gen-123" || result.Model != "openai/gpt-4o-mini" || result.Provider != "OpenAI" {
+				t.Errorf("unexpected metadata: %+v", result)
+			}
+			if result.Usage.PromptTokens != 1000 || result.Usage.CompletionTokens != 20 {
+				t.Errorf("unexpected usage: %+v", result.Usage)
+			}
+			if math.Abs(result.Cost-tt.wantCost) > 1e-9 {
+				t.Errorf("expected cost %v, got %v", tt.wantCost, result.Cost)
+			}
+			if result.Latency <= 0 {
+				t.Error("expected latency to be measured")
+			}
+		})
+	}
+}
+
+func TestWithObserver(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/stream":
+			w.Header().Set("Content-Type", "text/event-stream")
+			io.WriteString(w, "data: [DONE]\n\n")
+		case "/error":
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"error": {"message": "bad"}}`)
+		default:
+			io.WriteString(w, completionBody)
+		}
+	}))
+	defer srv.Close()
+
+	var observations []Observation
+
+	client := NewClient(srv.URL,
+		WithPricing(map[string]Pricing{"openai/gpt-4o-mini": {Prompt: 0.15, Completion: 0.6}}),
+		WithObserver(func(o Observation) { observations = append(observations, o) }),
+	)
+
+	if _, err := client.ChatCompletion(context.Background(), "qual meu saldo"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, path := range []string{"/stream", "/error"} {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+
+		resp, err := client.Do(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if len(body) == 0 {
+			t.Errorf("%s: expected the body to be handed back", path)
+		}
+	}
+
+	if len(observations) != 3 {
+		t.Fatalf("expected 3 observations, got %d", len(observations))
+	}
+
+	completion := observations[0]
+	if completion.Status != http.StatusOK || completion.Model != "openai/gpt-4o-mini" || completion.Usage.TotalTokens != 1020 {
+		t.Errorf("unexpected completion observation: %+v", completion)
+	}
+	if math.Abs(completion.Cost-0.000162) > 1e-9 {
+		t.Errorf("expected cost 0.000162, got %v", completion.Cost)
+	}
+	if completion.Latency <= 0 {
+		t.Error("expected latency to be measured")
+	}
+
+	if stream := observations[1]; stream.Status != http.StatusOK || stream.Usage.TotalTokens != 0 {
+		t.Errorf("unexpected stream observation: %+v", stream)
+	}
+
+	if failed := observations[2]; failed.Status != http.StatusBadRequest || failed.Model != "" {
+		t.Errorf("unexpected error observation: %+v", failed)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"ivr-service/client/openrouter"
+	"ivr-service/internal/classifier"
+	"ivr-service/internal/handler"
+)
+
+const (
+	baseURL = "https://openrouter.ai/api/v1"
+
+	defaultPort = "8080"
+
+	// llmTimeout leaves the local classifier time to answer within the load
+	// test client timeout when OpenRouter is slow
+	llmTimeout      = 10 * time.Second
+	shutdownTimeout = 10 * time.Second
+)
+
+type config struct {
+	Port             string
+	OpenRouterAPIKey string
+	// OpenRouterModel is the model classifying intents, required along with
+	// the API key for the LLM to be used.
+	OpenRouterModel string
+}
+
+func main() {
+	if err := run(loadConfig()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func loadConfig() config {
+	cfg := config{
+		Port:             os.Getenv("PORT"),
+		OpenRouterAPIKey: os.Getenv("OPENROUTER_API_KEY"),
+		OpenRouterModel:  os.Getenv("OPENROUTER_MODEL"),
+	}
+
+	if cfg.Port == "" {
+		cfg.Port = defaultPort
+	}
+
+	return cfg
+}
+
+// newClassifier uses the LLM backed by the local baseline, or the baseline
+// alone when no API key or model is configured: the client default model is
+// a placeholder OpenRouter rejects.
+func newClassifier(cfg config) classifier.Classifier {
+	local := classifier.NewLocal(classifier.DefaultExamples())
+
+	if cfg.OpenRouterAPIKey == "" {
+		log.Println("OPENROUTER_API_KEY not set, using the local classifier only")
+		return local
+	}
+	if cfg.OpenRouterModel == "" {
+		log.Println("OPENROUTER_MODEL not set, using the local classifier only")
+		return local
+	}
+
+	client := openrouter.NewClient(baseURL,
+		openrouter.WithAuth(cfg.OpenRouterAPIKey),
+		openrouter.WithTimeout(llmTimeout),
+		openrouter.WithCircuitBreaker(openrouter.BreakerPolicy{}),
+		openrouter.WithModels(cfg.OpenRouterModel),
+	)
+	llm := classifier.NewLLM(client, openrouter.DefaultCatalog)
+
+	return classifier.Fallback(llm, local)
+}
+
+func run(cfg config) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Addr:              net.JoinHostPort("", cfg.Port),
+		Handler:           handler.New(newClassifier(cfg)),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("listening on %s", srv.Addr)
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
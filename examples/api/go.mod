@@ -0,0 +1,3 @@
+module ivr-service
+
+go 1.25
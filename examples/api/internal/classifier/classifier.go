@@ -0,0 +1,43 @@
+// Package classifier maps a customer intent to one of the IVR services.
+package classifier
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnknownIntent is returned when an intent cannot be matched to any
+// service.
+var ErrUnknownIntent = errors.New("service not identified")
+
+// Service is the answer of a Classifier.
+type Service struct {
+	ID   uint8  `json:"service_id"`
+	Name string `json:"service_name"`
+}
+
+// Classifier finds the service that best fits an intent.
+type Classifier interface {
+	Classify(ctx context.Context, intent string) (Service, error)
+}
+
+// Func adapts a function to the Classifier interface.
+type Func func(ctx context.Context, intent string) (Service, error)
+
+func (f Func) Classify(ctx context.Context, intent string) (Service, error) {
+	return f(ctx, intent)
+}
+
+// Fallback asks primary first and falls back to secondary when primary
+// fails, e.g. an LLM backed by the local baseline. An intent the caller
+// gave up on is not retried.
+func Fallback(primary, secondary Classifier) Classifier {
+	return Func(func(ctx context.Context, intent string) (Service, error) {
+		service, err := primary.Classify(ctx, intent)
+		if err == nil || ctx.Err() != nil {
+			return service, err
+		}
+
+		return secondary.Classify(ctx, intent)
+	})
+}
@@ -0,0 +1,73 @@
+package classifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFallback(t *testing.T) {
+	saldo := Service{ID: 12, Name: "Consulta do Saldo"}
+	boom := errors.New("boom")
+
+	answer := func(service Service, err error) Classifier {
+		return Func(func(context.Context, string) (Service, error) { return service, err })
+	}
+
+	tests := []struct {
+		name      string
+		primary   Classifier
+		secondary Classifier
+		want      Service
+		wantErr   error
+	}{
+		{
+			name:      "primary answers",
+			primary:   answer(saldo, nil),
+			secondary: answer(Service{}, boom),
+			want:      saldo,
+		},
+		{
+			name:      "primary fails",
+			primary:   answer(Service{}, boom),
+			secondary: answer(saldo, nil),
+			want:      saldo,
+		},
+		{
+			name:      "both fail",
+			primary:   answer(Service{}, boom),
+			secondary: answer(Service{}, ErrUnknownIntent),
+			wantErr:   ErrUnknownIntent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Fallback(tt.primary, tt.secondary).Classify(context.Background(), "qual meu saldo")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFallbackCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	primary := Func(func(ctx context.Context, _ string) (Service, error) {
+		cancel()
+		return Service{}, ctx.Err()
+	})
+	secondary := Func(func(context.Context, string) (Service, error) {
+		t.Error("secondary called after the caller gave up")
+		return Service{}, nil
+	})
+
+	if _, err := Fallback(primary, secondary).Classify(ctx, "qual meu saldo"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package classifier
+
+import (
+	"context"
+	"fmt"
+
+	"ivr-service/client/openrouter"
+)
+
+// LLM classifies intents with a chat completion through OpenRouter.
+type LLM struct {
+	client  *openrouter.Client
+	catalog openrouter.Catalog
+}
+
+// NewLLM returns an LLM classifier using client. Answers are checked against
+// catalog, which should be the one the client was built with, and the
+// service name is always taken from it rather than from the model.
+func NewLLM(client *openrouter.Client, catalog openrouter.Catalog) *LLM {
+	return &LLM{client: client, catalog: catalog}
+}
+
+func (l *LLM) Classify(ctx context.Context, intent string) (Service, error) {
+	data, err := l.client.ChatCompletion(ctx, intent)
+	if err != nil {
+		return Service{}, err
+	}
+
+	if data.ServiceID == 0 {
+		return Service{}, ErrUnknownIntent
+	}
+
+	service, ok := l.catalog.Lookup(data.ServiceID)
+	if !ok {
+		return Service{}, fmt.Errorf("%w: model answered unknown service_id %d", openrouter.ErrInvalidOutput, data.ServiceID)
+	}
+
+	return Service{ID: service.ID, Name: service.Name}, nil
+}
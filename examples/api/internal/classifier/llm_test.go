@@ -0,0 +1,80 @@
+package classifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ivr-service/client/openrouter"
+)
+
+func TestLLMClassify(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []openrouter.Option
+		content   string
+		want      Service
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name:    "name taken from the catalog",
+			opts:    []openrouter.Option{openrouter.WithCatalog(nil)},
+			content: `{"service_id": 12, "service_name": "consulta saldo"}`,
+			want:    Service{ID: 12, Name: "Consulta do Saldo"},
+		},
+		{
+			name:      "name checked by the client",
+			content:   `{"service_id": 12, "service_name": "consulta saldo"}`,
+			wantErr:   true,
+			wantErrIs: openrouter.ErrInvalidOutput,
+		},
+		{
+			name:      "unknown intent",
+			opts:      []openrouter.Option{openrouter.WithCatalog(nil)},
+			content:   `{"service_id": 0, "service_name": ""}`,
+			wantErr:   true,
+			wantErrIs: ErrUnknownIntent,
+		},
+		{
+			name:      "service outside the catalog",
+			content:   `{"service_id": 42, "service_name": "Cartões de Crédito"}`,
+			wantErr:   true,
+			wantErrIs: openrouter.ErrInvalidOutput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(map[string]any{
+					"choices": []any{map[string]any{"message": map[string]any{"content": tt.content}}},
+				})
+			}))
+			defer srv.Close()
+
+			llm := NewLLM(openrouter.NewClient(srv.URL, tt.opts...), openrouter.DefaultCatalog)
+
+			got, err := llm.Classify(context.Background(), "qual meu saldo")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("expected error %v, got %v", tt.wantErrIs, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
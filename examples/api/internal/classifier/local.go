@@ -0,0 +1,193 @@
+package classifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"ivr-service/assets"
+)
+
+// stemLength truncates tokens so that inflections share a stem, e.g.
+// "cancelar" and "cancelamento".
+const stemLength = 5
+
+var (
+	accents = strings.NewReplacer(
+		"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+		"é", "e", "è", "e", "ê", "e",
+		"í", "i", "ì", "i", "î", "i",
+		"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+		"ú", "u", "ù", "u", "û", "u", "ü", "u",
+		"ç", "c",
+	)
+
+	stopwords = map[string]bool{
+		"com": true, "como": true, "das": true, "dos": true, "estou": true,
+		"meu": true, "minha": true, "para": true, "por": true, "pra": true,
+		"qual": true, "que": true, "quero": true, "uma": true,
+	}
+)
+
+// Example is a labelled intent the local classifier learns from.
+type Example struct {
+	Service Service
+	Intent  string
+}
+
+// LoadExamples reads examples in the format of
+// assets/intents_pre_loaded.csv: a "service_id;service_name;intent" header
+// followed by one example per line.
+func LoadExamples(r io.Reader) ([]Example, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = 3
+
+	if _, err := reader.Read(); err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	var examples []Example
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read example: %w", err)
+		}
+
+		id, err := strconv.ParseUint(record[0], 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service_id %q: %w", record[0], err)
+		}
+
+		examples = append(examples, Example{
+			Service: Service{ID: uint8(id), Name: record[1]},
+			Intent:  record[2],
+		})
+	}
+
+	return examples, nil
+}
+
+// DefaultExamples returns the pre-loaded intents embedded in the binary.
+func DefaultExamples() []Example {
+	examples, err := LoadExamples(bytes.NewReader(assets.IntentsPreLoaded))
+	if err != nil {
+		panic(fmt.Sprintf("embedded intents: %v", err))
+	}
+
+	return examples
+}
+
+// Local is the baseline classifier: it needs no network and answers with
+// the service of the most similar example, comparing TF-IDF weighted word
+// stems by cosine similarity.
+type Local struct {
+	examples []Example
+	vectors  []map[string]float64
+	idf      map[string]float64
+}
+
+// NewLocal returns a Local classifier learning from examples.
+func NewLocal(examples []Example) *Local {
+	df := make(map[string]int)
+	for _, example := range examples {
+		for term := range termCounts(example.Intent) {
+			df[term]++
+		}
+	}
+
+	idf := make(map[string]float64, len(df))
+	for term, n := range df {
+		idf[term] = math.Log(1 + float64(len(examples))/float64(n))
+	}
+
+	l := &Local{examples: examples, idf: idf}
+	for _, example := range examples {
+		l.vectors = append(l.vectors, l.vector(example.Intent))
+	}
+
+	return l
+}
+
+func (l *Local) Classify(ctx context.Context, intent string) (Service, error) {
+	if err := ctx.Err(); err != nil {
+		return Service{}, err
+	}
+
+	query := l.vector(intent)
+
+	best, bestScore := -1, 0.0
+	for i, vector := range l.vectors {
+		var score float64
+		for term, weight := range query {
+			score += weight * vector[term]
+		}
+
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+
+	if best < 0 {
+		return Service{}, ErrUnknownIntent
+	}
+
+	return l.examples[best].Service, nil
+}
+
+// vector returns the unit length TF-IDF vector of text. Terms never seen in
+// the examples carry no weight.
+func (l *Local) vector(text string) map[string]float64 {
+	vector := make(map[string]float64)
+
+	var norm float64
+	for term, count := range termCounts(text) {
+		idf, ok := l.idf[term]
+		if !ok {
+			continue
+		}
+
+		weight := float64(count) * idf
+		vector[term] = weight
+		norm += weight * weight
+	}
+
+	norm = math.Sqrt(norm)
+	for term := range vector {
+		vector[term] /= norm
+	}
+
+	return vector
+}
+
+// termCounts splits text into lowercase, unaccented word stems, dropping
+// short words and stopwords.
+func termCounts(text string) map[string]int {
+	words := strings.FieldsFunc(accents.Replace(strings.ToLower(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	counts := make(map[string]int)
+	for _, word := range words {
+		if len(word) < 3 || stopwords[word] {
+			continue
+		}
+
+		if runes := []rune(word); len(runes) > stemLength {
+			word = string(runes[:stemLength])
+		}
+		counts[word]++
+	}
+
+	return counts
+}
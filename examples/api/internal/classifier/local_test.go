@@ -0,0 +1,129 @@
+package classifier
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadExamples(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			input: "service_id;service_name;intent\n12;Consulta do Saldo;qual meu saldo\n7;Cancelamento de cartão;cancelar cartão\n",
+			want:  2,
+		},
+		{
+			name:    "empty",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "invalid service_id",
+			input:   "service_id;service_name;intent\ndoze;Consulta do Saldo;qual meu saldo\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing field",
+			input:   "service_id;service_name;intent\n12;qual meu saldo\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			examples, err := LoadExamples(strings.NewReader(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(examples) != tt.want {
+				t.Errorf("expected %d examples, got %d", tt.want, len(examples))
+			}
+		})
+	}
+}
+
+func TestDefaultExamples(t *testing.T) {
+	examples := DefaultExamples()
+	if len(examples) != 93 {
+		t.Errorf("expected 93 examples, got %d", len(examples))
+	}
+
+	services := make(map[uint8]bool)
+	for _, example := range examples {
+		services[example.Service.ID] = true
+	}
+	if len(services) != 16 {
+		t.Errorf("expected 16 services, got %d", len(services))
+	}
+}
+
+func TestLocalClassify(t *testing.T) {
+	local := NewLocal(DefaultExamples())
+
+	tests := []struct {
+		intent  string
+		want    uint8
+		wantErr error
+	}{
+		{intent: "Quero cancelar meu cartão", want: 7},
+		{intent: "QUAL O SALDO DA MINHA CONTA?", want: 12},
+		{intent: "esqueci a senha do aplicativo", want: 10},
+		{intent: "meu cartao foi roubado", want: 11},
+		{intent: "quero falar com um atendente", want: 15},
+		{intent: "xyzzy", wantErr: ErrUnknownIntent},
+		{intent: "", wantErr: ErrUnknownIntent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.intent, func(t *testing.T) {
+			got, err := local.Classify(context.Background(), tt.intent)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if got.ID != tt.want {
+				t.Errorf("expected service %d, got %d (%s)", tt.want, got.ID, got.Name)
+			}
+		})
+	}
+}
+
+func TestLocalClassifyTrainingSet(t *testing.T) {
+	examples := DefaultExamples()
+	local := NewLocal(examples)
+
+	for _, example := range examples {
+		got, err := local.Classify(context.Background(), example.Intent)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", example.Intent, err)
+			continue
+		}
+
+		if got != example.Service {
+			t.Errorf("%q: expected %+v, got %+v", example.Intent, example.Service, got)
+		}
+	}
+}
+
+func TestLocalClassifyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := NewLocal(DefaultExamples()).Classify(ctx, "qual meu saldo"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+// Package handler implements the HTTP API described in the challenge README.
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"ivr-service/internal/classifier"
+)
+
+// maxBodyBytes bounds the find-service request body.
+const maxBodyBytes = 1 << 16
+
+type (
+	FindServiceRequest struct {
+		Intent string `json:"intent"`
+	}
+
+	FindServiceResponse struct {
+		Success bool                `json:"success"`
+		Data    *classifier.Service `json:"data,omitempty"`
+		Error   string              `json:"error,omitempty"`
+	}
+
+	HealthzResponse struct {
+		Status string `json:"status"`
+	}
+)
+
+// New returns the API routes, classifying intents with c.
+func New(c classifier.Classifier) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/find-service", findService(c))
+	mux.HandleFunc("GET /api/healthz", healthz)
+
+	return mux
+}
+
+func findService(c classifier.Classifier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req FindServiceRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, FindServiceResponse{Error: "invalid request body"})
+			return
+		}
+
+		if strings.TrimSpace(req.Intent) == "" {
+			writeJSON(w, http.StatusBadRequest, FindServiceResponse{Error: "intent is required"})
+			return
+		}
+
+		service, err := c.Classify(r.Context(), req.Intent)
+		switch {
+		case errors.Is(err, classifier.ErrUnknownIntent):
+			writeJSON(w, http.StatusOK, FindServiceResponse{Error: err.Error()})
+		case err != nil:
+			log.Printf("failed to classify intent %q: %v", req.Intent, err)
+			writeJSON(w, http.StatusInternalServerError, FindServiceResponse{Error: "failed to classify intent"})
+		default:
+			writeJSON(w, http.StatusOK, FindServiceResponse{Success: true, Data: &service})
+		}
+	}
+}
+
+func healthz(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, HealthzResponse{Status: "ok"})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("failed to write response: %v", err)
+	}
+}
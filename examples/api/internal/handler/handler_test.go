@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ivr-service/internal/classifier"
+)
+
+func TestFindService(t *testing.T) {
+	saldo := classifier.Service{ID: 12, Name: "Consulta do Saldo"}
+
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		service    classifier.Service
+		err        error
+		wantStatus int
+		want       FindServiceResponse
+	}{
+		{
+			name:       "classified",
+			method:     http.MethodPost,
+			body:       `{"intent": "qual meu saldo"}`,
+			service:    saldo,
+			wantStatus: http.StatusOK,
+			want:       FindServiceResponse{Success: true, Data: &saldo},
+		},
+		{
+			name:       "unknown intent",
+			method:     http.MethodPost,
+			body:       `{"intent": "xyzzy"}`,
+			err:        classifier.ErrUnknownIntent,
+			wantStatus: http.StatusOK,
+			want:       FindServiceResponse{Error: classifier.ErrUnknownIntent.Error()},
+		},
+		{
+			name:       "classifier error",
+			method:     http.MethodPost,
+			body:       `{"intent": "qual meu saldo"}`,
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			want:       FindServiceResponse{Error: "failed to classify intent"},
+		},
+		{
+			name:       "invalid JSON",
+			method:     http.MethodPost,
+			body:       `{"intent":`,
+			wantStatus: http.StatusBadRequest,
+			want:       FindServiceResponse{Error: "invalid request body"},
+		},
+		{
+			name:       "empty intent",
+			method:     http.MethodPost,
+			body:       `{"intent": "  "}`,
+			wantStatus: http.StatusBadRequest,
+			want:       FindServiceResponse{Error: "intent is required"},
+		},
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := classifier.Func(func(context.Context, string) (classifier.Service, error) {
+				return tt.service, tt.err
+			})
+
+			rec := httptest.NewRecorder()
+			New(c).ServeHTTP(rec, httptest.NewRequest(tt.method, "/api/find-service", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus == http.StatusMethodNotAllowed {
+				return
+			}
+
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("expected content type application/json, got %s", ct)
+			}
+
+			var got FindServiceResponse
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if got.Success != tt.want.Success || got.Error != tt.want.Error {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+			if (got.Data == nil) != (tt.want.Data == nil) || got.Data != nil && *got.Data != *tt.want.Data {
+				t.Errorf("expected data %+v, got %+v", tt.want.Data, got.Data)
+			}
+		})
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	rec := httptest.NewRecorder()
+	New(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/healthz", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	if body := strings.TrimSpace(rec.Body.String()); body != `{"status":"ok"}` {
+		t.Errorf(`expected {"status":"ok"}, got %s`, body)
+	}
+}
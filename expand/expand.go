@@ -0,0 +1,214 @@
+// Package expand grows the dataset with intents written by an LLM through
+// OpenRouter: paraphrases of a service, and hard negatives, intents of one
+// service worded like a confusable one. Generated items wait in a review
+// Queue until a reviewer accepts or rejects them, and accepted items are
+// published as a new version of the dataset.
+package expand
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"credsystem-hackathon/catalog"
+
+	"ivr-service/client/openrouter"
+)
+
+const (
+	DefaultParaphrases   = 5
+	DefaultHardNegatives = 3
+)
+
+// ErrInvalidOutput is returned when the model answer is not the requested
+// JSON list of intents.
+var ErrInvalidOutput = errors.New("invalid model output")
+
+// Pair is two services whose intents are easily mistaken for each other.
+type Pair struct {
+	A, B uint8
+}
+
+// DefaultConfusables lists the pairs of services the classifiers mix up
+// most, like the agreement slip and the invoice slip.
+var DefaultConfusables = []Pair{
+	{A: 2, B: 3},   // segunda via de boleto de acordo / de fatura
+	{A: 7, B: 11},  // cancelamento / perda e roubo
+	{A: 4, B: 5},   // status de entrega / status de cartão
+	{A: 5, B: 9},   // status de cartão / desbloqueio
+	{A: 10, B: 16}, // senha / token de proposta
+	{A: 1, B: 12},  // consulta limite / consulta do saldo
+	{A: 3, B: 13},  // segunda via de fatura / pagamento de contas
+	{A: 14, B: 15}, // reclamações / atendimento humano
+}
+
+const systemPrompt = `Você escreve frases curtas de clientes de um cartão de crédito falando com a URA (atendimento eletrônico) de uma financeira brasileira.
+Escreva em português do Brasil, como o cliente falaria ou digitaria: frases diretas, variando vocabulário, formalidade e estrutura, sem numeração nem aspas.
+Responda apenas com o JSON pedido.`
+
+const paraphrasePrompt = `Escreva %d frases diferentes de clientes que querem o serviço "%s".
+Não repita nem copie estes exemplos:
+%s`
+
+const hardNegativePrompt = `Escreva %d frases diferentes de clientes que querem o serviço "%s", mas que usem palavras e estrutura parecidas com as do serviço "%s", de modo que um classificador desatento as confunda.
+Cada frase deve pertencer sem ambiguidade a "%[2]s".
+Exemplos de "%[2]s":
+%[4]s
+Exemplos de "%[3]s":
+%[5]s`
+
+// Generator asks an LLM for new intents of the catalog services.
+type Generator struct {
+	client  *openrouter.Client
+	catalog *catalog.Catalog
+}
+
+// NewGenerator returns a Generator sending prompts through client. The
+// intents of c are the examples given in the prompts.
+func NewGenerator(client *openrouter.Client, c *catalog.Catalog) *Generator {
+	return &Generator{client: client, catalog: c}
+}
+
+// Paraphrases returns up to n new intents of service id.
+func (g *Generator) Paraphrases(ctx context.Context, id uint8, n int) ([]Item, error) {
+	service, ok := g.catalog.ByID(id)
+	if !ok {
+		return nil, fmt.Errorf("unknown service_id %d", id)
+	}
+
+	prompt := fmt.Sprintf(paraphrasePrompt, n, service.Name, g.examples(id))
+
+	texts, model, err := g.generate(ctx, prompt, n)
+	if err != nil {
+		return nil, err
+	}
+
+	return newItems(texts, service, KindParaphrase, nil, model), nil
+}
+
+// HardNegatives returns up to n new intents of service id worded like the
+// intents of service like.
+func (g *Generator) HardNegatives(ctx context.Context, id, like uint8, n int) ([]Item, error) {
+	service, ok := g.catalog.ByID(id)
+	if !ok {
+		return nil, fmt.Errorf("unknown service_id %d", id)
+	}
+	confusable, ok := g.catalog.ByID(like)
+	if !ok {
+		return nil, fmt.Errorf("unknown service_id %d", like)
+	}
+
+	prompt := fmt.Sprintf(hardNegativePrompt, n, service.Name, confusable.Name, g.examples(id), g.examples(like))
+
+	texts, model, err := g.generate(ctx, prompt, n)
+	if err != nil {
+		return nil, err
+	}
+
+	return newItems(texts, service, KindHardNegative, &confusable, model), nil
+}
+
+// examples lists the catalog intents of service id, one per line.
+func (g *Generator) examples(id uint8) string {
+	var b strings.Builder
+	for _, intent := range g.catalog.Intents() {
+		if intent.ID == id {
+			fmt.Fprintf(&b, "- %s\n", intent.Text)
+		}
+	}
+
+	return b.String()
+}
+
+// generate sends prompt and returns at most n distinct non-empty intents,
+// along with the model that wrote them.
+func (g *Generator) generate(ctx context.Context, prompt string, n int) ([]string, string, error) {
+	resp, err := g.client.Chat(ctx, openrouter.OpenRouterRequest{
+		Messages: []openrouter.Message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: prompt},
+		},
+		ResponseFormat: &openrouter.ResponseFormat{
+			Type: "json_schema",
+			JSONSchema: &openrouter.JSONSchema{
+				Name:   "intents",
+				Schema: schema(n),
+			},
+		},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var out struct {
+		Intents []string `json:"intents"`
+	}
+	if err := json.Unmarshal([]byte(resp.Choices[0].Message.Content), &out); err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrInvalidOutput, err)
+	}
+
+	var texts []string
+	seen := make(map[string]bool)
+	for _, text := range out.Intents {
+		text = strings.TrimSpace(text)
+		key := catalog.Normalize(text)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		texts = append(texts, text)
+		if len(texts) == n {
+			break
+		}
+	}
+
+	return texts, resp.Model, nil
+}
+
+// schema is the JSON schema of an answer holding n intents.
+func schema(n int) map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"intents": map[string]any{
+				"type":     "array",
+				"items":    map[string]any{"type": "string"},
+				"minItems": n,
+				"maxItems": n,
+			},
+		},
+		"required":             []string{"intents"},
+		"additionalProperties": false,
+	}
+}
+
+func newItems(texts []string, service catalog.Service, kind Kind, confusable *catalog.Service, model string) []Item {
+	now := time.Now().UTC()
+
+	items := make([]Item, 0, len(texts))
+	for _, text := range texts {
+		items = append(items, Item{
+			Key:            key(service.ID, text),
+			Intent:         catalog.Intent{Service: service, Text: text},
+			Kind:           kind,
+			ConfusableWith: confusable,
+			Model:          model,
+			Status:         StatusPending,
+			CreatedAt:      now,
+		})
+	}
+
+	return items
+}
+
+// key identifies an intent of a service, ignoring case and accents.
+func key(id uint8, text string) string {
+	sum := sha256.Sum256(fmt.Appendf(nil, "%d;%s", id, catalog.Normalize(text)))
+	return hex.EncodeToString(sum[:6])
+}
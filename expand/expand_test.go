@@ -0,0 +1,160 @@
+package expand
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"credsystem-hackathon/catalog"
+
+	"ivr-service/client/openrouter"
+)
+
+func TestParaphrases(t *testing.T) {
+	srv := httptest.NewServer(MockRouter())
+	defer srv.Close()
+
+	g := NewGenerator(openrouter.NewClient(srv.URL, openrouter.WithModels(MockModel)), catalog.Default)
+
+	items, err := g.Paraphrases(context.Background(), 7, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(items) != 4 {
+		t.Fatalf("expected 4 items, got %d", len(items))
+	}
+	for _, item := range items {
+		if item.ID != 7 || item.Name != "Cancelamento de cartão" {
+			t.Errorf("%q: expected service 7, got %d %q", item.Text, item.ID, item.Name)
+		}
+		if item.Kind != KindParaphrase || item.ConfusableWith != nil {
+			t.Errorf("%q: expected a paraphrase, got %s %v", item.Text, item.Kind, item.ConfusableWith)
+		}
+		if item.Status != StatusPending || item.Model != MockModel || item.Key == "" {
+			t.Errorf("unexpected item %+v", item)
+		}
+	}
+
+	if _, err := g.Paraphrases(context.Background(), 99, 4); err == nil {
+		t.Error("expected error for an unknown service, got nil")
+	}
+}
+
+func TestHardNegatives(t *testing.T) {
+	var prompt string
+
+	mock := MockRouter()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		var req openrouter.OpenRouterRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		prompt = req.Messages[len(req.Messages)-1].Content
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		mock.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	g := NewGenerator(openrouter.NewClient(srv.URL, openrouter.WithModels(MockModel)), catalog.Default)
+
+	items, err := g.HardNegatives(context.Background(), 2, 3, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+	for _, item := range items {
+		if item.ID != 2 || item.Kind != KindHardNegative {
+			t.Errorf("%q: expected a hard negative of service 2, got %d %s", item.Text, item.ID, item.Kind)
+		}
+		if item.ConfusableWith == nil || item.ConfusableWith.ID != 3 {
+			t.Errorf("%q: expected confusable with service 3, got %v", item.Text, item.ConfusableWith)
+		}
+	}
+
+	for _, want := range []string{"Segunda via de boleto de acordo", "Segunda via de Fatura"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected the prompt to mention %q, got %q", want, prompt)
+		}
+	}
+}
+
+func TestGenerateOutput(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		want      []string
+		wantErrIs error
+	}{
+		{
+			name:    "trimmed and deduplicated",
+			content: `{"intents": [" quero cancelar ", "", "Quero cancelar!", "encerrar o cartão"]}`,
+			want:    []string{"quero cancelar", "encerrar o cartão"},
+		},
+		{
+			name:    "at most n",
+			content: `{"intents": ["a", "b", "c", "d"]}`,
+			want:    []string{"a", "b", "c"},
+		},
+		{
+			name:      "not JSON",
+			content:   `1. quero cancelar`,
+			wantErrIs: ErrInvalidOutput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(map[string]any{
+					"model":   "test/model",
+					"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": tt.content}}},
+				})
+			}))
+			defer srv.Close()
+
+			g := NewGenerator(openrouter.NewClient(srv.URL, openrouter.WithModels(MockModel)), catalog.Default)
+
+			items, err := g.Paraphrases(context.Background(), 7, 3)
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("expected error %v, got %v", tt.wantErrIs, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []string
+			for _, item := range items {
+				got = append(got, item.Text)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDefaultConfusables(t *testing.T) {
+	for _, pair := range DefaultConfusables {
+		for _, id := range []uint8{pair.A, pair.B} {
+			if _, ok := catalog.Default.ByID(id); !ok {
+				t.Errorf("%v: unknown service_id %d", pair, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package expand
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"ivr-service/client/openrouter"
+)
+
+// MockModel is the model name reported by MockRouter.
+const MockModel = "mock/router"
+
+// MockRouter answers chat completions like OpenRouter does, with as many
+// placeholder intents as the maxItems of the requested schema. The intents
+// derive from the prompt, so the same prompt always gets the same answer
+// and different prompts never share one. It lets expand run in tests and
+// dry runs without an API key.
+func MockRouter() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var req openrouter.OpenRouterRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		n := 1
+		if req.ResponseFormat != nil && req.ResponseFormat.JSONSchema != nil {
+			properties, _ := req.ResponseFormat.JSONSchema.Schema["properties"].(map[string]any)
+			intents, _ := properties["intents"].(map[string]any)
+			if max, ok := intents["maxItems"].(float64); ok {
+				n = int(max)
+			}
+		}
+
+		h := sha256.New()
+		for _, m := range req.Messages {
+			h.Write([]byte(m.Content))
+		}
+		prompt := hex.EncodeToString(h.Sum(nil))[:8]
+
+		texts := make([]string, n)
+		for i := range texts {
+			texts[i] = fmt.Sprintf("intenção simulada %s %d", prompt, i+1)
+		}
+		content, _ := json.Marshal(map[string][]string{"intents": texts})
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":    "mock-" + prompt,
+			"model": MockModel,
+			"choices": []map[string]any{
+				{"message": map[string]string{"role": "assistant", "content": string(content)}},
+			},
+		})
+	})
+
+	return mux
+}
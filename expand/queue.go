@@ -0,0 +1,297 @@
+package expand
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"credsystem-hackathon/catalog"
+	"credsystem-hackathon/dataset"
+)
+
+// Kind tells how an item was generated.
+type Kind string
+
+const (
+	KindParaphrase   Kind = "paraphrase"
+	KindHardNegative Kind = "hard_negative"
+)
+
+// Status is the review state of an item.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusAccepted Status = "accepted"
+	StatusRejected Status = "rejected"
+)
+
+var (
+	// ErrNotFound is returned when reviewing an item missing from the queue.
+	ErrNotFound = errors.New("item not found")
+	// ErrPublished is returned when reviewing an item already published.
+	ErrPublished = errors.New("item already published")
+	// ErrNothingToPublish is returned by Publish when no accepted item is
+	// waiting for a version.
+	ErrNothingToPublish = errors.New("no accepted item to publish")
+)
+
+var versionFile = regexp.MustCompile(`^intents_v(\d+)\.csv$`)
+
+// Item is a generated intent waiting in the review queue.
+type Item struct {
+	Key string `json:"key"`
+	catalog.Intent
+	Kind Kind `json:"kind"`
+	// ConfusableWith is the service a hard negative is worded like.
+	ConfusableWith *catalog.Service `json:"confusable_with,omitempty"`
+	Model          string           `json:"model,omitempty"`
+	Status         Status           `json:"status"`
+	CreatedAt      time.Time        `json:"created_at"`
+	ReviewedAt     *time.Time       `json:"reviewed_at,omitempty"`
+	// Version is the dataset version the item was published in, 0 until
+	// it is.
+	Version int `json:"version,omitempty"`
+}
+
+// Queue is the review queue, stored as one JSON item per line. Every
+// change is written to disk before the method returns.
+type Queue struct {
+	mu    sync.Mutex
+	path  string
+	items []Item
+}
+
+// OpenQueue reads the queue at path, empty if the file does not exist yet.
+func OpenQueue(path string) (*Queue, error) {
+	q := &Queue{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var item Item
+		if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		q.items = append(q.items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return q, nil
+}
+
+// Items returns the items in the order they were added.
+func (q *Queue) Items() []Item {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return append([]Item(nil), q.items...)
+}
+
+// Add appends the items whose intent is not in the queue yet, whatever
+// its status, so that rejected intents are not offered again. It returns
+// how many were added.
+func (q *Queue) Add(items []Item) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	seen := make(map[string]bool, len(q.items))
+	for _, item := range q.items {
+		seen[catalog.Normalize(item.Text)] = true
+	}
+
+	added := 0
+	for _, item := range items {
+		key := catalog.Normalize(item.Text)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		q.items = append(q.items, item)
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+
+	return added, q.save()
+}
+
+// Review sets the status of the item with the given key. A published item
+// can no longer change.
+func (q *Queue) Review(key string, status Status) error {
+	if status != StatusAccepted && status != StatusRejected && status != StatusPending {
+		return fmt.Errorf("invalid status %q", status)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i := range q.items {
+		item := &q.items[i]
+		if item.Key != key {
+			continue
+		}
+		if item.Version > 0 {
+			return fmt.Errorf("%w in version %d", ErrPublished, item.Version)
+		}
+
+		item.Status = status
+		item.ReviewedAt = nil
+		if status != StatusPending {
+			now := time.Now().UTC()
+			item.ReviewedAt = &now
+		}
+
+		return q.save()
+	}
+
+	return fmt.Errorf("%w: %s", ErrNotFound, key)
+}
+
+// Publish writes the next version of the dataset in dir: the latest
+// version, or base when dir holds none, followed by the accepted items not
+// published yet. It returns the new version and how many items it added.
+func (q *Queue) Publish(dir string, base []catalog.Intent) (int, int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var accepted []int
+	for i, item := range q.items {
+		if item.Status == StatusAccepted && item.Version == 0 {
+			accepted = append(accepted, i)
+		}
+	}
+	if len(accepted) == 0 {
+		return 0, 0, ErrNothingToPublish
+	}
+
+	version, intents, err := Latest(dir, base)
+	if err != nil {
+		return 0, 0, err
+	}
+	version++
+
+	for _, i := range accepted {
+		intents = append(intents, q.items[i].Intent)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, 0, err
+	}
+	path := VersionPath(dir, version)
+	if err := dataset.WriteFile(path, intents); err != nil {
+		return 0, 0, err
+	}
+
+	for _, i := range accepted {
+		q.items[i].Version = version
+	}
+
+	// roll the version back when the queue cannot record it, or the items
+	// would be published again on top of a version that already has them
+	if err := q.save(); err != nil {
+		for _, i := range accepted {
+			q.items[i].Version = 0
+		}
+		return 0, 0, errors.Join(err, os.Remove(path))
+	}
+
+	return version, len(accepted), nil
+}
+
+// save replaces the queue file atomically, so that a crash never leaves a
+// truncated queue behind.
+func (q *Queue) save() error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	for _, item := range q.items {
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+	}
+
+	tmp := q.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, q.path)
+}
+
+// VersionPath returns the file of a dataset version in dir.
+func VersionPath(dir string, version int) string {
+	return filepath.Join(dir, fmt.Sprintf("intents_v%d.csv", version))
+}
+
+// Latest returns the highest dataset version in dir and its intents, or
+// version 0 and base when dir holds no version yet.
+func Latest(dir string, base []catalog.Intent) (int, []catalog.Intent, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, nil, err
+	}
+
+	latest := 0
+	for _, entry := range entries {
+		m := versionFile.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		if v, err := strconv.Atoi(m[1]); err == nil && v > latest {
+			latest = v
+		}
+	}
+
+	if latest == 0 {
+		return 0, append([]catalog.Intent(nil), base...), nil
+	}
+
+	intents, err := dataset.ReadFile(VersionPath(dir, latest))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return latest, intents, nil
+}
+
+// Fresh drops the items whose intent is already in known, ignoring case
+// and accents.
+func Fresh(items []Item, known []catalog.Intent) []Item {
+	seen := make(map[string]bool, len(known))
+	for _, intent := range known {
+		seen[catalog.Normalize(intent.Text)] = true
+	}
+
+	var fresh []Item
+	for _, item := range items {
+		if !seen[catalog.Normalize(item.Text)] {
+			fresh = append(fresh, item)
+		}
+	}
+
+	return fresh
+}
@@ -0,0 +1,226 @@
+package expand
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"credsystem-hackathon/catalog"
+	"credsystem-hackathon/dataset"
+)
+
+func TestQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+
+	q, err := OpenQueue(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(q.Items()) != 0 {
+		t.Fatalf("expected an empty queue, got %v", q.Items())
+	}
+
+	items := []Item{
+		item(7, "quero encerrar meu cartão"),
+		item(11, "meu cartão foi levado"),
+		item(7, "Quero encerrar meu cartao"),
+	}
+	added, err := q.Add(items)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if added != 2 {
+		t.Errorf("expected 2 items added, got %d", added)
+	}
+
+	if err := q.Review(items[1].Key, StatusRejected); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// a rejected intent is not offered again
+	if added, _ := q.Add([]Item{item(11, "meu cartão foi levado")}); added != 0 {
+		t.Errorf("expected the rejected intent to stay out, got %d added", added)
+	}
+
+	reopened, err := OpenQueue(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(reopened.Items(), q.Items()) {
+		t.Errorf("expected %+v, got %+v", q.Items(), reopened.Items())
+	}
+
+	got := reopened.Items()[1]
+	if got.Status != StatusRejected || got.ReviewedAt == nil {
+		t.Errorf("expected a reviewed rejected item, got %+v", got)
+	}
+}
+
+func TestQueueReview(t *testing.T) {
+	q, err := OpenQueue(filepath.Join(t.TempDir(), "queue.jsonl"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pending := item(7, "quero encerrar meu cartão")
+	if _, err := q.Add([]Item{pending}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := q.Review("missing", StatusAccepted); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected error %v, got %v", ErrNotFound, err)
+	}
+	if err := q.Review(pending.Key, "maybe"); err == nil {
+		t.Error("expected error for an invalid status, got nil")
+	}
+
+	if err := q.Review(pending.Key, StatusAccepted); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := q.Publish(t.TempDir(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := q.Review(pending.Key, StatusRejected); !errors.Is(err, ErrPublished) {
+		t.Errorf("expected error %v, got %v", ErrPublished, err)
+	}
+}
+
+func TestPublish(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "versions")
+	base := []catalog.Intent{intent(7, "cancelar cartão")}
+
+	q, err := OpenQueue(filepath.Join(t.TempDir(), "queue.jsonl"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, _, err := q.Publish(dir, base); !errors.Is(err, ErrNothingToPublish) {
+		t.Errorf("expected error %v, got %v", ErrNothingToPublish, err)
+	}
+
+	items := []Item{
+		item(7, "quero encerrar meu cartão"),
+		item(11, "meu cartão foi levado"),
+		item(2, "boleto do acordo"),
+	}
+	if _, err := q.Add(items); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	q.Review(items[0].Key, StatusAccepted)
+	q.Review(items[1].Key, StatusRejected)
+
+	version, added, err := q.Publish(dir, base)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != 1 || added != 1 {
+		t.Errorf("expected version 1 with 1 item, got version %d with %d", version, added)
+	}
+
+	q.Review(items[2].Key, StatusAccepted)
+	if version, added, err = q.Publish(dir, base); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != 2 || added != 1 {
+		t.Errorf("expected version 2 with 1 item, got version %d with %d", version, added)
+	}
+
+	v1, err := dataset.ReadFile(VersionPath(dir, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []catalog.Intent{base[0], items[0].Intent}; !reflect.DeepEqual(v1, want) {
+		t.Errorf("expected version 1 %v, got %v", want, v1)
+	}
+
+	latest, v2, err := Latest(dir, base)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []catalog.Intent{base[0], items[0].Intent, items[2].Intent}; latest != 2 || !reflect.DeepEqual(v2, want) {
+		t.Errorf("expected version 2 %v, got version %d %v", want, latest, v2)
+	}
+
+	data, err := os.ReadFile(VersionPath(dir, 2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if issues := dataset.Validate(data, catalog.Default); len(issues) > 0 {
+		t.Errorf("unexpected issues: %v", issues)
+	}
+
+	for _, it := range q.Items() {
+		want := map[string]int{items[0].Key: 1, items[1].Key: 0, items[2].Key: 2}[it.Key]
+		if it.Version != want {
+			t.Errorf("%q: expected version %d, got %d", it.Text, want, it.Version)
+		}
+	}
+}
+
+func TestPublish_SaveFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	dir := t.TempDir()
+
+	q, err := OpenQueue(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	accepted := item(7, "quero encerrar meu cartão")
+	if _, err := q.Add([]Item{accepted}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := q.Review(accepted.Key, StatusAccepted); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// a directory in place of the temporary file makes the save fail
+	if err := os.Mkdir(path+".tmp", 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := q.Publish(dir, nil); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if _, err := os.Stat(VersionPath(dir, 1)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the version file to be removed, got %v", err)
+	}
+	if got := q.Items()[0].Version; got != 0 {
+		t.Errorf("expected the item to stay unpublished, got version %d", got)
+	}
+
+	if err := os.Remove(path + ".tmp"); err != nil {
+		t.Fatal(err)
+	}
+	if version, added, err := q.Publish(dir, nil); err != nil || version != 1 || added != 1 {
+		t.Errorf("expected version 1 with 1 item, got version %d with %d, error %v", version, added, err)
+	}
+}
+
+func TestFresh(t *testing.T) {
+	items := []Item{item(7, "Cancelar cartão!"), item(7, "quero encerrar meu cartão")}
+
+	fresh := Fresh(items, []catalog.Intent{intent(7, "cancelar cartao")})
+	if len(fresh) != 1 || fresh[0].Key != items[1].Key {
+		t.Errorf("expected only %q, got %v", items[1].Text, fresh)
+	}
+}
+
+func intent(id uint8, text string) catalog.Intent {
+	service, _ := catalog.Default.ByID(id)
+	return catalog.Intent{Service: service, Text: text}
+}
+
+func item(id uint8, text string) Item {
+	return Item{
+		Key:       key(id, text),
+		Intent:    intent(id, text),
+		Kind:      KindParaphrase,
+		Status:    StatusPending,
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
@@ -0,0 +1,148 @@
+package expand
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+
+	"credsystem-hackathon/catalog"
+)
+
+var reviewPage = template.Must(template.New("review").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<title>Revisão de intenções</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #ddd; padding: .4em; text-align: left; vertical-align: top; }
+nav a { margin-right: 1em; }
+nav a.current { font-weight: bold; }
+form { display: inline; }
+.message { background: #eef; padding: .5em; }
+</style>
+</head>
+<body>
+<h1>Revisão de intenções</h1>
+{{with .Message}}<p class="message">{{.}}</p>{{end}}
+<nav>
+{{range .Tabs}}<a href="/?status={{.Status}}"{{if .Current}} class="current"{{end}}>{{.Status}} ({{.Count}})</a>{{end}}
+</nav>
+<form method="post" action="/publish"><button>Publicar aceitos</button></form>
+<table>
+<tr><th>Serviço</th><th>Intenção</th><th>Tipo</th><th>Confundível com</th><th>Modelo</th><th>Versão</th><th></th></tr>
+{{range .Items}}
+<tr>
+<td>{{.ID}} - {{.Name}}</td>
+<td>{{.Text}}</td>
+<td>{{.Kind}}</td>
+<td>{{with .ConfusableWith}}{{.ID}} - {{.Name}}{{end}}</td>
+<td>{{.Model}}</td>
+<td>{{if .Version}}v{{.Version}}{{end}}</td>
+<td>{{if not .Version}}
+{{if ne .Status "accepted"}}<form method="post" action="/items/{{.Key}}/accepted"><button>Aceitar</button></form>{{end}}
+{{if ne .Status "rejected"}}<form method="post" action="/items/{{.Key}}/rejected"><button>Rejeitar</button></form>{{end}}
+{{end}}</td>
+</tr>
+{{else}}
+<tr><td colspan="7">Nenhum item.</td></tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
+
+type (
+	reviewTab struct {
+		Status  Status
+		Count   int
+		Current bool
+	}
+
+	reviewData struct {
+		Message string
+		Tabs    []reviewTab
+		Items   []Item
+	}
+)
+
+// NewReviewHandler returns a web UI listing the items of q by status, with
+// buttons to accept or reject them and to publish the accepted ones as a
+// new version of the dataset in dir, starting from base. Cross-origin POSTs
+// are rejected, so another page open in the reviewer's browser cannot
+// review or publish items.
+func NewReviewHandler(q *Queue, dir string, base []catalog.Intent) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		status := Status(r.URL.Query().Get("status"))
+		if status == "" {
+			status = StatusPending
+		}
+
+		data := reviewData{Message: r.URL.Query().Get("message")}
+
+		counts := make(map[Status]int)
+		for _, item := range q.Items() {
+			counts[item.Status]++
+			if item.Status == status {
+				data.Items = append(data.Items, item)
+			}
+		}
+		for _, s := range []Status{StatusPending, StatusAccepted, StatusRejected} {
+			data.Tabs = append(data.Tabs, reviewTab{Status: s, Count: counts[s], Current: s == status})
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := reviewPage.Execute(w, data); err != nil {
+			log.Printf("review page: %v", err)
+		}
+	})
+
+	mux.HandleFunc("POST /items/{key}/{status}", func(w http.ResponseWriter, r *http.Request) {
+		err := q.Review(r.PathValue("key"), Status(r.PathValue("status")))
+		switch {
+		case errors.Is(err, ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, ErrPublished):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// stay on the list the reviewer came from
+		redirect := "/"
+		if ref, err := url.Parse(r.Referer()); err == nil && ref.Path == "/" {
+			query := ref.Query()
+			query.Del("message")
+			ref.RawQuery = query.Encode()
+			redirect = ref.RequestURI()
+		}
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+	})
+
+	mux.HandleFunc("POST /publish", func(w http.ResponseWriter, r *http.Request) {
+		version, added, err := q.Publish(dir, base)
+		var message string
+		switch {
+		case errors.Is(err, ErrNothingToPublish):
+			message = "Nenhum item aceito para publicar."
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		default:
+			message = fmt.Sprintf("Versão %d publicada com %d novos itens em %s.", version, added, VersionPath(dir, version))
+		}
+
+		http.Redirect(w, r, "/?status=accepted&message="+url.QueryEscape(message), http.StatusSeeOther)
+	})
+
+	return http.NewCrossOriginProtection().Handler(mux)
+}
@@ -0,0 +1,91 @@
+package expand
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReviewHandler(t *testing.T) {
+	q, err := OpenQueue(filepath.Join(t.TempDir(), "queue.jsonl"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items := []Item{item(7, "quero encerrar meu cartão"), item(11, "meu cartão foi levado")}
+	if _, err := q.Add(items); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dir := t.TempDir()
+	h := NewReviewHandler(q, dir, nil)
+
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+		return w
+	}
+
+	// a page on another origin cannot act on the queue
+	cross := httptest.NewRequest(http.MethodPost, "/publish", nil)
+	cross.Header.Set("Origin", "http://evil.example")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, cross)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for a cross-origin POST, got %d", w.Code)
+	}
+
+	same := httptest.NewRequest(http.MethodPost, "/items/missing/accepted", nil)
+	same.Header.Set("Origin", "http://"+same.Host)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, same)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected a same-origin POST to go through, got %d", w.Code)
+	}
+
+	w = serve(http.MethodGet, "/")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	for _, want := range []string{"quero encerrar meu cartão", "meu cartão foi levado", "pending (2)", "/items/" + items[0].Key + "/accepted"} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("expected the page to contain %q", want)
+		}
+	}
+
+	if w := serve(http.MethodPost, "/items/"+items[0].Key+"/accepted"); w.Code != http.StatusSeeOther {
+		t.Errorf("expected status 303, got %d", w.Code)
+	}
+	if w := serve(http.MethodPost, "/items/"+items[1].Key+"/rejected"); w.Code != http.StatusSeeOther {
+		t.Errorf("expected status 303, got %d", w.Code)
+	}
+	if w := serve(http.MethodPost, "/items/missing/accepted"); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+	if w := serve(http.MethodPost, "/items/"+items[0].Key+"/maybe"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+
+	w = serve(http.MethodGet, "/?status=accepted")
+	if body := w.Body.String(); !strings.Contains(body, "quero encerrar meu cartão") || strings.Contains(body, "meu cartão foi levado") {
+		t.Errorf("expected only the accepted item, got %s", body)
+	}
+
+	w = serve(http.MethodPost, "/publish")
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("expected status 303, got %d", w.Code)
+	}
+	if location := w.Header().Get("Location"); !strings.Contains(location, "Vers%C3%A3o+1") {
+		t.Errorf("expected a version 1 message, got %s", location)
+	}
+
+	if version, intents, _ := Latest(dir, nil); version != 1 || len(intents) != 1 {
+		t.Errorf("expected version 1 with 1 intent, got version %d with %v", version, intents)
+	}
+
+	if w := serve(http.MethodPost, "/items/"+items[0].Key+"/rejected"); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", w.Code)
+	}
+}
@@ -0,0 +1,7 @@
+module credsystem-hackathon
+
+go 1.25
+
+require ivr-service v0.0.0
+
+replace ivr-service => ./examples/api
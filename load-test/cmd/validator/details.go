@@ -0,0 +1,831 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	hardestIntentsFile = "hardest-intents.html"
+	numServices        = 16
+)
+
+// latencyBuckets are the upper bounds (exclusive, in milliseconds) of the
+// latency histogram buckets. Anything slower lands in a final open bucket.
+var latencyBuckets = []int64{100, 250, 500, 1000, 2000, 5000, 10000}
+
+// SuiteRun pairs a test suite name with its result file
+type SuiteRun struct {
+	Suite  string
+	Result *TestResult
+}
+
+// ConfusionCell is a single cell of the expected x returned matrix
+type ConfusionCell struct {
+	Count     int
+	Intensity float64 // share of the row total, used as the heatmap alpha
+	Diagonal  bool
+}
+
+// ConfusionRow holds the answers given for one expected service
+type ConfusionRow struct {
+	ServiceID   int
+	ServiceName string
+	Total       int
+	Cells       []ConfusionCell
+}
+
+// ConfusionMatrix maps expected services (rows) to returned services
+// (columns). The last two columns collect the answers with the right ID but
+// the wrong name, which the scorer counts as failures, and the errors and
+// out-of-range IDs.
+type ConfusionMatrix struct {
+	Columns []string
+	Rows    []ConfusionRow
+}
+
+// FailedIntent is a request the participant got wrong
+type FailedIntent struct {
+	Suite string
+	RequestRecord
+}
+
+// HistogramBucket is one bar of the latency histogram
+type HistogramBucket struct {
+	Label   string
+	Count   int
+	Percent float64 // bar width relative to the tallest bucket
+}
+
+// ParticipantDetail is the data rendered on a participant drill-down page
+type ParticipantDetail struct {
+	Participant   ParticipantResult
+	TotalRequests int
+	Matrix        ConfusionMatrix
+	Failures      []FailedIntent
+	Histogram     []HistogramBucket
+	BackLink      string
+	HardestLink   string
+	GeneratedAt   string
+}
+
+// HardIntent aggregates how all participants did on a single intent.
+// Missed and Tested count participants, not requests, so a team sent the
+// intent in both suites counts once.
+type HardIntent struct {
+	Suite        string // the suites that sent the intent, e.g. "93, 80"
+	Intent       string
+	ExpectedID   int
+	ExpectedName string
+	Missed       int
+	Tested       int
+	MissRate     float64
+	WrongAnswers []string
+}
+
+// ServiceMissRate aggregates misses per expected service across participants
+type ServiceMissRate struct {
+	ServiceID   int
+	ServiceName string
+	Missed      int
+	Tested      int
+	MissRate    float64
+}
+
+// Suites returns the test runs available for the participant, in the
+// order they are executed by run.sh. Runs whose file failed validation are
+// left out, like they are from the score.
+func (p *ParticipantResult) Suites() []SuiteRun {
+	var runs []SuiteRun
+	if p.Test93 != nil && !p.discarded("93") {
+		runs = append(runs, SuiteRun{Suite: "93", Result: p.Test93})
+	}
+	if p.Test80 != nil && !p.discarded("80") {
+		runs = append(runs, SuiteRun{Suite: "80", Result: p.Test80})
+	}
+	return runs
+}
+
+// discarded reports whether the result file of suite failed validation
+func (p *ParticipantResult) discarded(suite string) bool {
+	return slices.ContainsFunc(p.Issues, func(issue ValidationIssue) bool {
+		return issue.Suite == suite && issue.Severity == SeverityError
+	})
+}
+
+// HasRequests reports whether any of the participant's result files carry
+// per-request records
+func (p *ParticipantResult) HasRequests() bool {
+	for _, run := range p.Suites() {
+		if len(run.Result.Requests) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// generateDetailPages writes one drill-down page per participant with
+// per-request records, plus the cross-participant hardest intents page.
+// It sets DetailPage on every participant that got a page.
+func generateDetailPages(pages pageWriter, participants []ParticipantResult, outputPath, detailsDir string) error {
+	baseDir := filepath.Dir(outputPath)
+	pagesDir := filepath.Join(baseDir, detailsDir)
+
+	backLink, err := relativeLink(pagesDir, outputPath)
+	if err != nil {
+		return err
+	}
+
+	hardestLink, err := relativeLink(pagesDir, filepath.Join(baseDir, hardestIntentsFile))
+	if err != nil {
+		return err
+	}
+
+	detailTmpl := template.Must(template.New("detail").Funcs(templateFuncs()).Parse(detailTemplate))
+	generatedAt := time.Now().Format("2006-01-02 15:04:05")
+
+	var withRequests []ParticipantResult
+
+	for i := range participants {
+		p := &participants[i]
+		if !p.HasRequests() {
+			continue
+		}
+
+		fileName := p.Name + ".html"
+		p.DetailPage = path.Join(filepath.ToSlash(detailsDir), fileName)
+
+		detail := buildParticipantDetail(*p)
+		detail.BackLink = backLink
+		detail.HardestLink = hardestLink
+		detail.GeneratedAt = generatedAt
+
+		if err := pages.WritePage(filepath.Join(pagesDir, fileName), detailTmpl, &detail); err != nil {
+			return fmt.Errorf("failed to write detail page for %s: %w", p.Name, err)
+		}
+
+		withRequests = append(withRequests, *p)
+	}
+
+	if len(withRequests) == 0 {
+		return nil
+	}
+
+	intents, services := buildHardestIntents(withRequests)
+	hardestTmpl := template.Must(template.New("hardest").Funcs(templateFuncs()).Parse(hardestTemplate))
+
+	data := struct {
+		Participants int
+		Intents      []HardIntent
+		Services     []ServiceMissRate
+		BackLink     string
+		GeneratedAt  string
+	}{
+		Participants: len(withRequests),
+		Intents:      intents,
+		Services:     services,
+		BackLink:     filepath.Base(outputPath),
+		GeneratedAt:  generatedAt,
+	}
+
+	return pages.WritePage(filepath.Join(baseDir, hardestIntentsFile), hardestTmpl, data)
+}
+
+func buildParticipantDetail(p ParticipantResult) ParticipantDetail {
+	detail := ParticipantDetail{
+		Participant: p,
+	}
+
+	names := make(map[int]string, numServices)
+	var latencies []int64
+
+	for _, run := range p.Suites() {
+		for _, req := range run.Result.Requests {
+			detail.TotalRequests++
+			latencies = append(latencies, req.LatencyMs)
+
+			if _, ok := names[req.ExpectedID]; !ok {
+				names[req.ExpectedID] = req.ExpectedName
+			}
+
+			if !req.Success {
+				detail.Failures = append(detail.Failures, FailedIntent{Suite: run.Suite, RequestRecord: req})
+			}
+		}
+	}
+
+	detail.Matrix = buildConfusionMatrix(p.Suites(), names)
+	detail.Histogram = buildLatencyHistogram(latencies)
+
+	return detail
+}
+
+func buildConfusionMatrix(runs []SuiteRun, names map[int]string) ConfusionMatrix {
+	// column numServices is the "wrong name" bucket for the right ID with
+	// the wrong name, numServices+1 the "other" bucket for errors and
+	// unknown IDs
+	const wrongName, other = numServices, numServices + 1
+
+	counts := make([][]int, numServices)
+	for i := range counts {
+		counts[i] = make([]int, numServices+2)
+	}
+
+	for _, run := range runs {
+		for _, req := range run.Result.Requests {
+			if req.ExpectedID < 1 || req.ExpectedID > numServices {
+				continue
+			}
+
+			col := other
+			switch {
+			case req.ReturnedID == req.ExpectedID && !req.Success && req.Error == "":
+				col = wrongName
+			case req.ReturnedID >= 1 && req.ReturnedID <= numServices:
+				col = req.ReturnedID - 1
+			}
+
+			counts[req.ExpectedID-1][col]++
+		}
+	}
+
+	matrix := ConfusionMatrix{}
+	for id := 1; id <= numServices; id++ {
+		matrix.Columns = append(matrix.Columns, fmt.Sprintf("%d", id))
+	}
+	matrix.Columns = append(matrix.Columns, "Wrong name", "Other")
+
+	for i, row := range counts {
+		total := 0
+		for _, c := range row {
+			total += c
+		}
+
+		cr := ConfusionRow{
+			ServiceID:   i + 1,
+			ServiceName: names[i+1],
+			Total:       total,
+		}
+
+		for j, c := range row {
+			cell := ConfusionCell{Count: c, Diagonal: i == j}
+			if total > 0 {
+				cell.Intensity = float64(c) / float64(total)
+			}
+			cr.Cells = append(cr.Cells, cell)
+		}
+
+		matrix.Rows = append(matrix.Rows, cr)
+	}
+
+	return matrix
+}
+
+func buildLatencyHistogram(latencies []int64) []HistogramBucket {
+	buckets := make([]HistogramBucket, len(latencyBuckets)+1)
+
+	lower := int64(0)
+	for i, upper := range latencyBuckets {
+		buckets[i].Label = fmt.Sprintf("%d-%dms", lower, upper)
+		lower = upper
+	}
+	buckets[len(latencyBuckets)].Label = fmt.Sprintf("≥%dms", lower)
+
+	for _, l := range latencies {
+		i := sort.Search(len(latencyBuckets), func(i int) bool {
+			return l < latencyBuckets[i]
+		})
+		buckets[i].Count++
+	}
+
+	highest := 0
+	for _, b := range buckets {
+		highest = max(highest, b.Count)
+	}
+
+	if highest > 0 {
+		for i := range buckets {
+			buckets[i].Percent = float64(buckets[i].Count) / float64(highest) * 100
+		}
+	}
+
+	return buckets
+}
+
+// buildHardestIntents ranks intents by how many participants got them wrong
+func buildHardestIntents(participants []ParticipantResult) ([]HardIntent, []ServiceMissRate) {
+	type key struct {
+		intent string
+		id     int
+	}
+
+	byIntent := make(map[key]*HardIntent)
+	suites := make(map[key][]string)
+	tested := make(map[key]map[string]bool)
+	missed := make(map[key]map[string]bool)
+	wrong := make(map[key]map[string]int)
+	byService := make(map[int]*ServiceMissRate)
+
+	for _, p := range participants {
+		for _, run := range p.Suites() {
+			for _, req := range run.Result.Requests {
+				k := key{intent: req.Intent, id: req.ExpectedID}
+
+				hi, ok := byIntent[k]
+				if !ok {
+					hi = &HardIntent{
+						Intent:       req.Intent,
+						ExpectedID:   req.ExpectedID,
+						ExpectedName: req.ExpectedName,
+					}
+					byIntent[k] = hi
+					tested[k] = make(map[string]bool)
+					missed[k] = make(map[string]bool)
+					wrong[k] = make(map[string]int)
+				}
+				if !slices.Contains(suites[k], run.Suite) {
+					suites[k] = append(suites[k], run.Suite)
+				}
+
+				sm, ok := byService[req.ExpectedID]
+				if !ok {
+					sm = &ServiceMissRate{ServiceID: req.ExpectedID, ServiceName: req.ExpectedName}
+					byService[req.ExpectedID] = sm
+				}
+
+				tested[k][p.Name] = true
+				sm.Tested++
+
+				if req.Success {
+					continue
+				}
+
+				missed[k][p.Name] = true
+				sm.Missed++
+
+				answer := "error"
+				if req.ReturnedID != 0 || req.ReturnedName != "" {
+					answer = fmt.Sprintf("%d - %s", req.ReturnedID, req.ReturnedName)
+				}
+				wrong[k][answer]++
+			}
+		}
+	}
+
+	var intents []HardIntent
+	for k, hi := range byIntent {
+		hi.Tested, hi.Missed = len(tested[k]), len(missed[k])
+		if hi.Missed == 0 {
+			continue
+		}
+
+		hi.Suite = strings.Join(suites[k], ", ")
+		hi.MissRate = float64(hi.Missed) / float64(hi.Tested) * 100
+
+		answers := wrong[k]
+		for answer := range answers {
+			hi.WrongAnswers = append(hi.WrongAnswers, answer)
+		}
+		sort.Slice(hi.WrongAnswers, func(i, j int) bool {
+			a, b := hi.WrongAnswers[i], hi.WrongAnswers[j]
+			if answers[a] != answers[b] {
+				return answers[a] > answers[b]
+			}
+			return a < b
+		})
+		for i, answer := range hi.WrongAnswers {
+			hi.WrongAnswers[i] = fmt.Sprintf("%s (×%d)", answer, answers[answer])
+		}
+
+		intents = append(intents, *hi)
+	}
+
+	sort.Slice(intents, func(i, j int) bool {
+		if intents[i].Missed != intents[j].Missed {
+			return intents[i].Missed > intents[j].Missed
+		}
+		if intents[i].MissRate != intents[j].MissRate {
+			return intents[i].MissRate > intents[j].MissRate
+		}
+		if intents[i].Suite != intents[j].Suite {
+			return intents[i].Suite > intents[j].Suite
+		}
+		return intents[i].Intent < intents[j].Intent
+	})
+
+	var services []ServiceMissRate
+	for _, sm := range byService {
+		sm.MissRate = float64(sm.Missed) / float64(sm.Tested) * 100
+		services = append(services, *sm)
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].MissRate != services[j].MissRate {
+			return services[i].MissRate > services[j].MissRate
+		}
+		return services[i].ServiceID < services[j].ServiceID
+	})
+
+	return intents, services
+}
+
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"formatTime": formatTime,
+	}
+}
+
+// pageWriter stores a rendered page of the generated site under name
+type pageWriter interface {
+	WritePage(name string, tmpl *template.Template, data any) error
+}
+
+// diskPages writes pages as files, creating parent directories as needed
+type diskPages struct{}
+
+func (diskPages) WritePage(name string, tmpl *template.Template, data any) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	file, err := os.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer file.Close()
+
+	return tmpl.Execute(file, data)
+}
+
+// memoryPages keeps pages in memory keyed by their slash separated name,
+// which is how the serve subcommand holds the site between rebuilds
+type memoryPages map[string][]byte
+
+func (m memoryPages) WritePage(name string, tmpl *template.Template, data any) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	m[path.Clean(filepath.ToSlash(name))] = buf.Bytes()
+	return nil
+}
+
+// relativeLink returns a slash separated link to target as seen from a page
+// living in fromDir
+func relativeLink(fromDir, target string) (string, error) {
+	rel, err := filepath.Rel(fromDir, target)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve link to %s: %w", target, err)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+const detailStyles = `
+        * {
+            margin: 0;
+            padding: 0;
+            box-sizing: border-box;
+        }
+
+        body {
+            font-family: 'Segoe UI', Tahoma, Geneva, Verdana, sans-serif;
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            padding: 20px;
+            min-height: 100vh;
+        }
+
+        .container {
+            max-width: 1400px;
+            margin: 0 auto;
+            background: white;
+            border-radius: 12px;
+            box-shadow: 0 20px 60px rgba(0,0,0,0.3);
+            overflow: hidden;
+        }
+
+        .header {
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white;
+            padding: 40px;
+            text-align: center;
+        }
+
+        .header h1 {
+            font-size: 2.2em;
+            margin-bottom: 10px;
+            text-shadow: 2px 2px 4px rgba(0,0,0,0.2);
+        }
+
+        .header .subtitle {
+            font-size: 1.1em;
+            opacity: 0.9;
+        }
+
+        .header a {
+            color: white;
+        }
+
+        .generated-at {
+            font-size: 0.9em;
+            opacity: 0.8;
+            margin-top: 10px;
+        }
+
+        .section {
+            padding: 30px 40px;
+            border-bottom: 3px solid #e9ecef;
+        }
+
+        .section h2 {
+            color: #495057;
+            margin-bottom: 20px;
+            font-size: 1.5em;
+        }
+
+        .section p.hint {
+            color: #6c757d;
+            margin-bottom: 15px;
+        }
+
+        .data-table {
+            width: 100%;
+            border-collapse: collapse;
+            box-shadow: 0 2px 10px rgba(0,0,0,0.1);
+            border-radius: 8px;
+            overflow: hidden;
+        }
+
+        .data-table thead {
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white;
+        }
+
+        .data-table th {
+            padding: 12px;
+            text-align: left;
+            font-weight: 600;
+            font-size: 0.85em;
+        }
+
+        .data-table td {
+            padding: 10px 12px;
+            border-bottom: 1px solid #e9ecef;
+        }
+
+        .matrix {
+            border-collapse: collapse;
+            font-size: 0.85em;
+        }
+
+        .matrix th, .matrix td {
+            border: 1px solid #e9ecef;
+            padding: 6px 8px;
+            text-align: center;
+            min-width: 34px;
+        }
+
+        .matrix th.row-label {
+            text-align: left;
+            font-weight: 500;
+            color: #495057;
+            white-space: nowrap;
+        }
+
+        .matrix td.empty {
+            color: #ced4da;
+        }
+
+        .histogram-row {
+            display: flex;
+            align-items: center;
+            margin-bottom: 8px;
+        }
+
+        .histogram-label {
+            width: 130px;
+            color: #6c757d;
+            font-weight: 500;
+        }
+
+        .histogram-bar {
+            height: 24px;
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            border-radius: 4px;
+            min-width: 2px;
+        }
+
+        .histogram-count {
+            margin-left: 10px;
+            color: #495057;
+            font-weight: 600;
+        }
+
+        .metric.success {
+            color: #28a745;
+        }
+
+        .metric.failed {
+            color: #dc3545;
+        }
+
+        .rank-badge {
+            display: inline-flex;
+            align-items: center;
+            justify-content: center;
+            width: 40px;
+            height: 40px;
+            border-radius: 50%;
+            font-weight: bold;
+            font-size: 1.1em;
+            background: rgba(255,255,255,0.25);
+            margin-right: 10px;
+        }
+`
+
+const detailTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Participant.DisplayName}} - Load Test Drill-down</title>
+    <style>` + detailStyles + `</style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1><span class="rank-badge">{{if .Participant.Rank}}{{.Participant.Rank}}{{else}}DQ{{end}}</span>{{.Participant.DisplayName}}</h1>
+            {{with .Participant.Team}}
+            <div class="subtitle">Team #{{.Number}}{{if .Members}} · 👥 {{range $i, $m := .Members}}{{if $i}}, {{end}}{{$m}}{{end}}{{end}}</div>
+            {{end}}
+            <div class="subtitle">
+                {{.TotalRequests}} requests ·
+                <span>{{.Participant.TotalSuccess}} success</span> ·
+                <span>{{.Participant.TotalFailed}} failed</span> ·
+                score {{printf "%.2f" .Participant.Score}}
+            </div>
+            <div class="generated-at">
+                <a href="{{.BackLink}}">← Back to rankings</a> ·
+                <a href="{{.HardestLink}}">Hardest intents</a> ·
+                Generated at: {{.GeneratedAt}}
+            </div>
+        </div>
+
+        <div class="section">
+            <h2>🧮 Confusion Matrix</h2>
+            <p class="hint">Rows are the expected service, columns the service returned. "Wrong name" collects the right ID with the wrong name, "Other" errors and IDs outside 1-16.</p>
+            <table class="matrix">
+                <thead>
+                    <tr>
+                        <th></th>
+                        {{range .Matrix.Columns}}<th>{{.}}</th>{{end}}
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Matrix.Rows}}
+                    <tr>
+                        <th class="row-label" title="{{.ServiceName}}">{{.ServiceID}}. {{.ServiceName}}</th>
+                        {{- range .Cells}}
+                        {{- if eq .Count 0}}
+                        <td class="empty">·</td>
+                        {{- else if .Diagonal}}
+                        <td style="background: rgba(40, 167, 69, {{printf "%.2f" .Intensity}})">{{.Count}}</td>
+                        {{- else}}
+                        <td style="background: rgba(220, 53, 69, {{printf "%.2f" .Intensity}})">{{.Count}}</td>
+                        {{- end}}
+                        {{- end}}
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+
+        <div class="section">
+            <h2>❌ Failed Intents ({{len .Failures}})</h2>
+            {{if .Failures}}
+            <table class="data-table">
+                <thead>
+                    <tr>
+                        <th>Suite</th>
+                        <th>Intent</th>
+                        <th>Expected</th>
+                        <th>Returned</th>
+                        <th>Latency</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Failures}}
+                    <tr>
+                        <td>{{.Suite}}</td>
+                        <td>{{.Intent}}</td>
+                        <td><span class="metric success">{{.ExpectedID}} - {{.ExpectedName}}</span></td>
+                        <td>
+                            {{if or .ReturnedID .ReturnedName}}
+                            <span class="metric failed">{{.ReturnedID}} - {{.ReturnedName}}</span>
+                            {{else}}
+                            <span class="metric failed">{{.Error}}</span>
+                            {{end}}
+                        </td>
+                        <td>{{.LatencyMs}}ms</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+            {{else}}
+            <p class="hint">No failures 🎉</p>
+            {{end}}
+        </div>
+
+        <div class="section">
+            <h2>⏱️ Latency Histogram</h2>
+            {{range .Histogram}}
+            <div class="histogram-row">
+                <span class="histogram-label">{{.Label}}</span>
+                <div class="histogram-bar" style="width: {{printf "%.1f" .Percent}}%"></div>
+                <span class="histogram-count">{{.Count}}</span>
+            </div>
+            {{end}}
+        </div>
+    </div>
+</body>
+</html>`
+
+const hardestTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Hardest Intents - Load Test Drill-down</title>
+    <style>` + detailStyles + `</style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>🔥 Hardest Intents</h1>
+            <div class="subtitle">Intents most participants got wrong, across {{.Participants}} participants with per-request records</div>
+            <div class="generated-at">
+                <a href="{{.BackLink}}">← Back to rankings</a> ·
+                Generated at: {{.GeneratedAt}}
+            </div>
+        </div>
+
+        <div class="section">
+            <h2>📉 Miss Rate by Service</h2>
+            <table class="data-table">
+                <thead>
+                    <tr>
+                        <th>Service</th>
+                        <th>Missed</th>
+                        <th>Tested</th>
+                        <th>Miss Rate</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Services}}
+                    <tr>
+                        <td>{{.ServiceID}} - {{.ServiceName}}</td>
+                        <td><span class="metric failed">{{.Missed}}</span></td>
+                        <td>{{.Tested}}</td>
+                        <td>{{printf "%.1f" .MissRate}}%</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+
+        <div class="section">
+            <h2>🧩 Intents ({{len .Intents}} with at least one miss)</h2>
+            <table class="data-table">
+                <thead>
+                    <tr>
+                        <th>Suite</th>
+                        <th>Intent</th>
+                        <th>Expected</th>
+                        <th>Teams Wrong</th>
+                        <th>Wrong Answers</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Intents}}
+                    <tr>
+                        <td>{{.Suite}}</td>
+                        <td>{{.Intent}}</td>
+                        <td>{{.ExpectedID}} - {{.ExpectedName}}</td>
+                        <td><span class="metric failed">{{.Missed}}/{{.Tested}}</span> ({{printf "%.0f" .MissRate}}%)</td>
+                        <td>{{range $i, $a := .WrongAnswers}}{{if $i}}<br>{{end}}{{$a}}{{end}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+    </div>
+</body>
+</html>`
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildConfusionMatrix(t *testing.T) {
+	tests := []struct {
+		name       string
+		requests   []RequestRecord
+		wantCounts map[[2]int]int // {expected, column} -> count, column 16 is Wrong name, 17 Other
+		wantTotals map[int]int
+	}{
+		{
+			name:       "empty",
+			wantCounts: map[[2]int]int{},
+			wantTotals: map[int]int{},
+		},
+		{
+			name: "hits and confusions",
+			requests: []RequestRecord{
+				{ExpectedID: 2, ReturnedID: 2, Success: true},
+				{ExpectedID: 2, ReturnedID: 3},
+				{ExpectedID: 2, ReturnedID: 3},
+				{ExpectedID: 7, ReturnedID: 11},
+			},
+			wantCounts: map[[2]int]int{{2, 1}: 1, {2, 2}: 2, {7, 10}: 1},
+			wantTotals: map[int]int{2: 3, 7: 1},
+		},
+		{
+			name: "errors and unknown IDs go to Other",
+			requests: []RequestRecord{
+				{ExpectedID: 5, Error: "timeout"},
+				{ExpectedID: 5, ReturnedID: 17},
+				{ExpectedID: 5, ReturnedID: -1},
+			},
+			wantCounts: map[[2]int]int{{5, 17}: 3},
+			wantTotals: map[int]int{5: 3},
+		},
+		{
+			name: "right ID with the wrong name is off the diagonal",
+			requests: []RequestRecord{
+				{ExpectedID: 4, ReturnedID: 4, ReturnedName: "Status do cartão"},
+				{ExpectedID: 4, ReturnedID: 4, Success: true},
+			},
+			wantCounts: map[[2]int]int{{4, 3}: 1, {4, 16}: 1},
+			wantTotals: map[int]int{4: 2},
+		},
+		{
+			name: "unknown expected IDs are skipped",
+			requests: []RequestRecord{
+				{ExpectedID: 0, ReturnedID: 1},
+				{ExpectedID: 17, ReturnedID: 1},
+			},
+			wantCounts: map[[2]int]int{},
+			wantTotals: map[int]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := []SuiteRun{{Suite: "93", Result: &TestResult{Requests: tt.requests}}}
+			matrix := buildConfusionMatrix(runs, map[int]string{2: "Segunda via de boleto de acordo"})
+
+			if len(matrix.Columns) != numServices+2 || matrix.Columns[numServices] != "Wrong name" || matrix.Columns[numServices+1] != "Other" {
+				t.Fatalf("expected %d columns ending with Wrong name and Other, got %v", numServices+2, matrix.Columns)
+			}
+			if len(matrix.Rows) != numServices {
+				t.Fatalf("expected %d rows, got %d", numServices, len(matrix.Rows))
+			}
+			if name := matrix.Rows[1].ServiceName; name != "Segunda via de boleto de acordo" {
+				t.Errorf("expected row 2 to be named, got %q", name)
+			}
+
+			for i, row := range matrix.Rows {
+				id := i + 1
+				if row.ServiceID != id || row.Total != tt.wantTotals[id] {
+					t.Errorf("row %d: expected service %d with total %d, got %d with %d", i, id, tt.wantTotals[id], row.ServiceID, row.Total)
+				}
+
+				for j, cell := range row.Cells {
+					want := tt.wantCounts[[2]int{id, j}]
+					if cell.Count != want {
+						t.Errorf("cell %d,%d: expected %d, got %d", id, j, want, cell.Count)
+					}
+					if cell.Diagonal != (i == j) {
+						t.Errorf("cell %d,%d: expected diagonal %v", id, j, i == j)
+					}
+					if row.Total > 0 && cell.Intensity != float64(want)/float64(row.Total) {
+						t.Errorf("cell %d,%d: expected intensity %v, got %v", id, j, float64(want)/float64(row.Total), cell.Intensity)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBuildLatencyHistogram(t *testing.T) {
+	tests := []struct {
+		name        string
+		latencies   []int64
+		wantCounts  []int
+		wantPercent []float64
+	}{
+		{
+			name:        "empty",
+			wantCounts:  []int{0, 0, 0, 0, 0, 0, 0, 0},
+			wantPercent: []float64{0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:        "upper bounds are exclusive",
+			latencies:   []int64{0, 99, 100, 249, 250, 9999, 10000},
+			wantCounts:  []int{2, 2, 1, 0, 0, 0, 1, 1},
+			wantPercent: []float64{100, 100, 50, 0, 0, 0, 50, 50},
+		},
+		{
+			name:        "slow requests land in the open bucket",
+			latencies:   []int64{20000, 60000, 1500},
+			wantCounts:  []int{0, 0, 0, 0, 1, 0, 0, 2},
+			wantPercent: []float64{0, 0, 0, 0, 50, 0, 0, 100},
+		},
+	}
+
+	wantLabels := []string{"0-100ms", "100-250ms", "250-500ms", "500-1000ms", "1000-2000ms", "2000-5000ms", "5000-10000ms", "≥10000ms"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := buildLatencyHistogram(tt.latencies)
+
+			var labels []string
+			var counts []int
+			var percent []float64
+			for _, b := range buckets {
+				labels = append(labels, b.Label)
+				counts = append(counts, b.Count)
+				percent = append(percent, b.Percent)
+			}
+
+			if !reflect.DeepEqual(labels, wantLabels) {
+				t.Errorf("expected labels %v, got %v", wantLabels, labels)
+			}
+			if !reflect.DeepEqual(counts, tt.wantCounts) {
+				t.Errorf("expected counts %v, got %v", tt.wantCounts, counts)
+			}
+			if !reflect.DeepEqual(percent, tt.wantPercent) {
+				t.Errorf("expected percents %v, got %v", tt.wantPercent, percent)
+			}
+		})
+	}
+}
+
+func TestBuildHardestIntents(t *testing.T) {
+	participant := func(name string, requests ...RequestRecord) ParticipantResult {
+		return ParticipantResult{Name: name, Test93: &TestResult{Requests: requests}}
+	}
+
+	hit := func(intent string, id int) RequestRecord {
+		return RequestRecord{Intent: intent, ExpectedID: id, ReturnedID: id, Success: true}
+	}
+	miss := func(intent string, id, returned int, name string) RequestRecord {
+		return RequestRecord{Intent: intent, ExpectedID: id, ReturnedID: returned, ReturnedName: name}
+	}
+
+	// computed at run time like the builder does, not as an exact constant
+	one, three := 1.0, 3.0
+	third := one / three * 100
+
+	tests := []struct {
+		name         string
+		participants []ParticipantResult
+		wantIntents  []HardIntent
+		wantServices []ServiceMissRate
+	}{
+		{
+			name:         "nobody missed",
+			participants: []ParticipantResult{participant("a", hit("saldo", 12))},
+			wantServices: []ServiceMissRate{{ServiceID: 12, Tested: 1}},
+		},
+		{
+			name: "most missed first, wrong answers by frequency",
+			participants: []ParticipantResult{
+				participant("a", miss("boleto", 2, 3, "Segunda via de Fatura"), miss("cancelar", 7, 11, "Perda e roubo"), hit("saldo", 12)),
+				participant("b", miss("boleto", 2, 3, "Segunda via de Fatura"), hit("cancelar", 7), hit("saldo", 12)),
+				participant("c", RequestRecord{Intent: "boleto", ExpectedID: 2, Error: "timeout"}, hit("cancelar", 7), hit("saldo", 12)),
+			},
+			wantIntents: []HardIntent{
+				{
+					Suite: "93", Intent: "boleto", ExpectedID: 2, Missed: 3, Tested: 3, MissRate: 100,
+					WrongAnswers: []string{"3 - Segunda via de Fatura (×2)", "error (×1)"},
+				},
+				{
+					Suite: "93", Intent: "cancelar", ExpectedID: 7, Missed: 1, Tested: 3, MissRate: third,
+					WrongAnswers: []string{"11 - Perda e roubo (×1)"},
+				},
+			},
+			wantServices: []ServiceMissRate{
+				{ServiceID: 2, Missed: 3, Tested: 3, MissRate: 100},
+				{ServiceID: 7, Missed: 1, Tested: 3, MissRate: third},
+				{ServiceID: 12, Tested: 3},
+			},
+		},
+		{
+			name: "a team missing an intent in both suites counts once",
+			participants: []ParticipantResult{{
+				Name:   "a",
+				Test93: &TestResult{Requests: []RequestRecord{miss("fatura", 3, 2, "Segunda via de boleto de acordo")}},
+				Test80: &TestResult{Requests: []RequestRecord{miss("fatura", 3, 2, "Segunda via de boleto de acordo")}},
+			}},
+			wantIntents: []HardIntent{
+				{Suite: "93, 80", Intent: "fatura", ExpectedID: 3, Missed: 1, Tested: 1, MissRate: 100, WrongAnswers: []string{"2 - Segunda via de boleto de acordo (×2)"}},
+			},
+			wantServices: []ServiceMissRate{{ServiceID: 3, Missed: 2, Tested: 2, MissRate: 100}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intents, services := buildHardestIntents(tt.participants)
+
+			if !reflect.DeepEqual(intents, tt.wantIntents) {
+				t.Errorf("expected intents %+v, got %+v", tt.wantIntents, intents)
+			}
+			if !reflect.DeepEqual(services, tt.wantServices) {
+				t.Errorf("expected services %+v, got %+v", tt.wantServices, services)
+			}
+		})
+	}
+}
+
+func TestSuites(t *testing.T) {
+	p := ParticipantResult{
+		Test93: &TestResult{Requests: []RequestRecord{{Intent: "a", ExpectedID: 1, LatencyMs: 10}}},
+		Test80: &TestResult{Requests: []RequestRecord{{Intent: "b", ExpectedID: 2, LatencyMs: 9000}}},
+		Issues: []ValidationIssue{
+			{Suite: "93", Severity: SeverityWarning, Message: "no schema_version"},
+			{Suite: "80", Severity: SeverityError, Message: "inconsistent totals"},
+		},
+	}
+
+	runs := p.Suites()
+	if len(runs) != 1 || runs[0].Suite != "93" {
+		t.Fatalf("expected only suite 93, got %+v", runs)
+	}
+
+	// the discarded run does not reach the cross-participant pages either
+	intents, services := buildHardestIntents([]ParticipantResult{p})
+	if len(intents) != 1 || intents[0].Intent != "a" {
+		t.Errorf("expected only the intent of suite 93, got %+v", intents)
+	}
+	if len(services) != 1 || services[0].ServiceID != 1 {
+		t.Errorf("expected only the service of suite 93, got %+v", services)
+	}
+}
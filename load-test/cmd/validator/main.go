@@ -1,20 +1,17 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
 	"html/template"
 	"os"
 	"path/filepath"
-	"sort"
-	"strconv"
-	"strings"
 	"time"
 )
 
 // TestResult represents the structure of the JSON test results
 type TestResult struct {
+	SchemaVersion int     `json:"schema_version"`
 	TotalRequests int     `json:"total_requests"`
 	Timestamp     string  `json:"timestamp"`
 	ElapsedTime   string  `json:"elapsed_time"`
@@ -25,31 +22,99 @@ type TestResult struct {
 	FastestTime   string  `json:"fastest_time"`
 	SlowestTime   string  `json:"slowest_time"`
 	AverageTime   string  `json:"average_time"`
+
+	// Requests holds one entry per intent sent by the load test. Older
+	// result files do not carry it, in which case no detail page is built.
+	Requests []RequestRecord `json:"requests,omitempty"`
+}
+
+// RequestRecord is the outcome of a single intent in a test run
+type RequestRecord struct {
+	Intent       string `json:"intent"`
+	ExpectedID   int    `json:"expected_service_id"`
+	ExpectedName string `json:"expected_service_name"`
+	ReturnedID   int    `json:"returned_service_id"`
+	ReturnedName string `json:"returned_service_name"`
+	Success      bool   `json:"success"`
+	LatencyMs    int64  `json:"latency_ms"`
+	Error        string `json:"error,omitempty"`
 }
 
 // ParticipantResult holds combined results for a participant
 type ParticipantResult struct {
-	Name         string
-	Test93       *TestResult
-	Test80       *TestResult
-	TotalSuccess int
-	TotalFailed  int
-	AvgTime93    float64 // in milliseconds
-	AvgTime80    float64 // in milliseconds
-	Score        float64
+	Name         string      `json:"name"`
+	Test93       *TestResult `json:"test_93,omitempty"`
+	Test80       *TestResult `json:"test_80,omitempty"`
+	TotalSuccess int         `json:"total_success"`
+	TotalFailed  int         `json:"total_failed"`
+	AvgTime93    float64     `json:"avg_time_93_ms"` // in milliseconds
+	AvgTime80    float64     `json:"avg_time_80_ms"` // in milliseconds
+	Score        float64     `json:"score"`
+	DetailPage   string      `json:"detail_page,omitempty"` // relative link to the drill-down page, empty when there are no per-request records
+
+	Issues []ValidationIssue `json:"issues,omitempty"`
+
+	Rank        int           `json:"rank"`   // 0 when disqualified
+	P95Ms       float64       `json:"p95_ms"` // 0 when there are no per-request records
+	SubmittedAt time.Time     `json:"submitted_at,omitzero"`
+	Flags       []RankingFlag `json:"flags,omitempty"`
+
+	Team *Team `json:"team,omitempty"` // nil when the directory has no entry in the teams file
+}
+
+// DisplayName is the team name from the teams file, falling back to the
+// directory name
+func (p *ParticipantResult) DisplayName() string {
+	if p.Team != nil {
+		return p.Team.Name
+	}
+	return p.Name
+}
+
+// Disqualified reports whether any of the participant's flags disqualifies it
+func (p *ParticipantResult) Disqualified() bool {
+	for _, f := range p.Flags {
+		if f.Disqualified {
+			return true
+		}
+	}
+	return false
+}
+
+// HasErrors reports whether any of the participant's result files was
+// discarded from scoring
+func (p *ParticipantResult) HasErrors() bool {
+	return hasErrors(p.Issues)
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		if err := runServe(os.Args[2:]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	participantesPath := flag.String("path", "../../participantes", "Path to participantes folder")
 	outputPath := flag.String("output", "results.html", "Output HTML file path")
+	detailsDir := flag.String("details", "details", "Directory, relative to the output file, for per-participant pages")
+	strict := flag.Bool("strict", false, "Fail when any result file does not pass validation")
+	rankingOptions := registerRankingFlags(flag.CommandLine)
 	flag.Parse()
 
+	opts, err := rankingOptions()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	if _, err := os.Stat(*participantesPath); os.IsNotExist(err) {
 		fmt.Printf("Error: Path '%s' does not exist\n", *participantesPath)
 		os.Exit(1)
 	}
 
-	participants, err := readAllParticipants(*participantesPath)
+	participants, err := loadRanking(*participantesPath, opts)
 	if err != nil {
 		fmt.Printf("Error reading participants: %v\n", err)
 		os.Exit(1)
@@ -60,18 +125,29 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Calculate scores and rank
-	for i := range participants {
-		participants[i].Score = calculateScore(&participants[i])
+	if *strict {
+		invalid := 0
+		for _, p := range participants {
+			if p.HasErrors() {
+				invalid++
+			}
+		}
+
+		if invalid > 0 {
+			fmt.Printf("Error: %d participant(s) have result files that failed validation\n", invalid)
+			os.Exit(1)
+		}
 	}
 
-	// Sort by score (higher is better)
-	sort.Slice(participants, func(i, j int) bool {
-		return participants[i].Score > participants[j].Score
-	})
+	// Generate drill-down pages before the ranking so it can link to them
+	err = generateDetailPages(diskPages{}, participants, *outputPath, *detailsDir)
+	if err != nil {
+		fmt.Printf("Error generating detail pages: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Generate HTML report
-	err = generateHTMLReport(participants, *outputPath)
+	err = generateHTMLReport(diskPages{}, participants, opts, *outputPath, false)
 	if err != nil {
 		fmt.Printf("Error generating HTML report: %v\n", err)
 		os.Exit(1)
@@ -80,7 +156,26 @@ func main() {
 	fmt.Printf("✅ Report generated successfully: %s\n", *outputPath)
 }
 
-func readAllParticipants(basePath string) ([]ParticipantResult, error) {
+// loadRanking reads every participant's results, scores them and returns
+// them sorted from best to worst
+func loadRanking(basePath string, opts RankingOptions) ([]ParticipantResult, error) {
+	participants, err := readAllParticipants(basePath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate scores and rank
+	for i := range participants {
+		participants[i].Score = calculateScore(&participants[i])
+	}
+
+	// Sort by score (higher is better), then by the tie-breakers
+	sortParticipants(participants, opts)
+
+	return participants, nil
+}
+
+func readAllParticipants(basePath string, opts RankingOptions) ([]ParticipantResult, error) {
 	var participants []ParticipantResult
 
 	entries, err := os.ReadDir(basePath)
@@ -88,6 +183,15 @@ func readAllParticipants(basePath string) ([]ParticipantResult, error) {
 		return nil, fmt.Errorf("failed to read directory: %w", err)
 	}
 
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+
+	teams := loadTeams(opts.TeamsFile, dirs)
+
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
@@ -96,18 +200,34 @@ func readAllParticipants(basePath string) ([]ParticipantResult, error) {
 		participantName := entry.Name()
 		resultsPath := filepath.Join(basePath, participantName, "results")
 
+		participantPath := filepath.Join(basePath, participantName)
+
+		runner, err := readRunnerReport(participantPath)
+		if err != nil {
+			fmt.Printf("Warning: participant '%s' has an unreadable runner report: %v\n", participantName, err)
+		}
+
+		// A participant whose service never came up has no results folder,
+		// but still has to show up as disqualified
+		healthzFailed := healthzFailure(participantPath, runner) != ""
+
 		// Check if results directory exists
-		if _, err := os.Stat(resultsPath); os.IsNotExist(err) {
+		if _, err := os.Stat(resultsPath); os.IsNotExist(err) && !healthzFailed {
 			fmt.Printf("Warning: No results folder for participant '%s', skipping\n", participantName)
 			continue
 		}
 
+		conformance, err := readConformance(participantPath)
+		if err != nil {
+			fmt.Printf("Warning: participant '%s' has unreadable contract check results: %v\n", participantName, err)
+		}
+
 		// Read test results
-		test93, err93 := readTestResult(filepath.Join(resultsPath, "93.json"))
-		test80, err80 := readTestResult(filepath.Join(resultsPath, "80.json"))
+		test93, issues93, err93 := readTestResult(filepath.Join(resultsPath, "93.json"), "93")
+		test80, issues80, err80 := readTestResult(filepath.Join(resultsPath, "80.json"), "80")
 
 		// Skip if both files are missing
-		if err93 != nil && err80 != nil {
+		if err93 != nil && err80 != nil && runner == nil && !healthzFailed {
 			fmt.Printf("Warning: No valid test results for participant '%s', skipping\n", participantName)
 			continue
 		}
@@ -116,19 +236,35 @@ func readAllParticipants(basePath string) ([]ParticipantResult, error) {
 			Name:   participantName,
 			Test93: test93,
 			Test80: test80,
+			Issues: append(issues93, issues80...),
+			Team:   teams[participantName],
+		}
+
+		for _, issue := range participant.Issues {
+			fmt.Printf("Warning: participant '%s' %s\n", participantName, issue)
+		}
+
+		// Calculate aggregated metrics. Files that failed validation are
+		// shown in the report but their numbers are never used: like
+		// missing files, they count as a suite where every request failed.
+		participant.AvgTime93 = participant.addSuite("93", test93, issues93)
+		participant.AvgTime80 = participant.addSuite("80", test80, issues80)
+
+		var latencies []int64
+		for _, run := range participant.Suites() {
+			for _, req := range run.Result.Requests {
+				latencies = append(latencies, req.LatencyMs)
+			}
 		}
+		participant.P95Ms = percentile(latencies, 95)
 
-		// Calculate aggregated metrics
-		if test93 != nil {
-			participant.TotalSuccess += test93.TotalSuccess
-			participant.TotalFailed += test93.TotalFailed
-			participant.AvgTime93 = parseTimeMs(test93.AverageTime)
+		if runner != nil {
+			participant.SubmittedAt = runner.SubmittedAt
 		}
 
-		if test80 != nil {
-			participant.TotalSuccess += test80.TotalSuccess
-			participant.TotalFailed += test80.TotalFailed
-			participant.AvgTime80 = parseTimeMs(test80.AverageTime)
+		participant.Flags = evaluateFlags(&participant, participantPath, runner, conformance, opts)
+		for _, f := range participant.Flags {
+			fmt.Printf("Warning: participant '%s' flagged %s: %s\n", participantName, f.Label, f.Detail)
 		}
 
 		participants = append(participants, participant)
@@ -137,31 +273,34 @@ func readAllParticipants(basePath string) ([]ParticipantResult, error) {
 	return participants, nil
 }
 
-func readTestResult(filePath string) (*TestResult, error) {
+// readTestResult reads and validates a result file. The error is only set
+// when the file cannot be read; decoding and schema problems are returned
+// as validation issues.
+func readTestResult(filePath, suite string) (*TestResult, []ValidationIssue, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return nil, err
-	}
-
-	var result TestResult
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		return nil, nil, err
 	}
 
-	return &result, nil
+	result, issues := validateTestResult(suite, data)
+	return result, issues, nil
 }
 
-// parseTimeMs extracts milliseconds from time strings like "3938ms"
-func parseTimeMs(timeStr string) float64 {
-	timeStr = strings.TrimSpace(timeStr)
-	timeStr = strings.TrimSuffix(timeStr, "ms")
-
-	val, err := strconv.ParseFloat(timeStr, 64)
-	if err != nil {
-		return 0
+// addSuite adds the totals of a suite to the participant and returns its
+// average time. A suite without a usable file, missing or failing
+// validation, counts as every intent failed at the client timeout, so that
+// dropping a file never costs less than failing the requests.
+func (p *ParticipantResult) addSuite(suite string, result *TestResult, issues []ValidationIssue) float64 {
+	if result == nil || hasErrors(issues) {
+		p.TotalFailed += expectedSuiteSizes[suite]
+		return clientTimeoutMs
 	}
 
-	return val
+	p.TotalSuccess += result.TotalSuccess
+	p.TotalFailed += result.TotalFailed
+	avg, _ := parseTimeMs(result.AverageTime)
+
+	return avg
 }
 
 // calculateScore computes a ranking score based on success rate, failures, and response time
@@ -189,34 +328,42 @@ func calculateScore(p *ParticipantResult) float64 {
 		score -= avgTime * timeWeight
 	}
 
+	// Penalties reported by the runner
+	for _, f := range p.Flags {
+		score -= f.Points
+	}
+
 	return score
 }
 
-func generateHTMLReport(participants []ParticipantResult, outputPath string) error {
-	funcMap := template.FuncMap{
-		"add": func(a, b int) int {
-			return a + b
-		},
-		"formatTime": formatTime,
-	}
-
-	tmpl := template.Must(template.New("report").Funcs(funcMap).Parse(htmlTemplate))
+// generateHTMLReport renders the ranking page. With live set the page
+// subscribes to the serve subcommand's event stream and reloads itself
+// whenever the ranking changes.
+func generateHTMLReport(pages pageWriter, participants []ParticipantResult, opts RankingOptions, outputPath string, live bool) error {
+	tmpl := template.Must(template.New("report").Funcs(templateFuncs()).Parse(htmlTemplate))
+	template.Must(tmpl.Parse(rankBadgeTemplate))
 
 	data := struct {
 		Participants []ParticipantResult
+		TieBreakers  []string
+		HardestPage  string
 		GeneratedAt  string
+		Live         bool
 	}{
 		Participants: participants,
+		TieBreakers:  opts.TieBreakerLabels(),
 		GeneratedAt:  time.Now().Format("2006-01-02 15:04:05"),
+		Live:         live,
 	}
 
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+	for _, p := range participants {
+		if p.DetailPage != "" {
+			data.HardestPage = hardestIntentsFile
+			break
+		}
 	}
-	defer file.Close()
 
-	return tmpl.Execute(file, data)
+	return pages.WritePage(outputPath, tmpl, data)
 }
 
 const htmlTemplate = `<!DOCTYPE html>
@@ -322,6 +469,14 @@ const htmlTemplate = `<!DOCTYPE html>
             border-left-color: #ffc107;
         }
 
+        .criteria-item.quality {
+            border-left-color: #6c757d;
+        }
+
+        .criteria-item.tiebreak {
+            border-left-color: #667eea;
+        }
+
         .rankings {
             padding: 40px;
         }
@@ -399,6 +554,33 @@ const htmlTemplate = `<!DOCTYPE html>
             color: #495057;
         }
 
+        .rank-dq {
+            background: #f8d7da;
+            color: #721c24;
+            font-size: 0.8em;
+        }
+
+        .ranking-table tbody tr.disqualified {
+            opacity: 0.6;
+        }
+
+        .flag-badge {
+            display: inline-block;
+            margin-left: 8px;
+            padding: 2px 8px;
+            border-radius: 10px;
+            font-size: 0.75em;
+            font-weight: 600;
+            background: #e2e3e5;
+            color: #383d41;
+            cursor: help;
+        }
+
+        .flag-badge.dq {
+            background: #f8d7da;
+            color: #721c24;
+        }
+
         .participant-name {
             font-weight: 600;
             color: #495057;
@@ -427,6 +609,28 @@ const htmlTemplate = `<!DOCTYPE html>
             margin-top: 40px;
         }
 
+        .participant-meta {
+            color: #6c757d;
+            font-size: 0.85em;
+            margin-top: 4px;
+        }
+
+        .participant-name a {
+            color: inherit;
+            text-decoration: none;
+            border-bottom: 1px dashed #667eea;
+        }
+
+        .drill-down-link {
+            margin: -20px 0 30px;
+            font-size: 1.05em;
+        }
+
+        .drill-down-link a {
+            color: #667eea;
+            font-weight: 600;
+        }
+
         .participant-card {
             background: #f8f9fa;
             border-radius: 12px;
@@ -458,6 +662,53 @@ const htmlTemplate = `<!DOCTYPE html>
             font-size: 1.5em;
         }
 
+        .quality-badge {
+            display: inline-block;
+            margin-left: 8px;
+            padding: 2px 8px;
+            border-radius: 10px;
+            font-size: 0.75em;
+            font-weight: 600;
+            background: #fff3cd;
+            color: #856404;
+            cursor: help;
+        }
+
+        .quality-badge.error {
+            background: #f8d7da;
+            color: #721c24;
+        }
+
+        .quality-issues {
+            background: #fff3cd;
+            border-left: 4px solid #ffc107;
+            border-radius: 8px;
+            padding: 15px 20px;
+            margin-top: 20px;
+            color: #856404;
+        }
+
+        .quality-issues.error {
+            background: #f8d7da;
+            border-left-color: #dc3545;
+            color: #721c24;
+        }
+
+        .quality-issues h4 {
+            margin-bottom: 10px;
+        }
+
+        .quality-issues ul {
+            padding-left: 20px;
+        }
+
+        .card-detail-link {
+            margin-left: auto;
+            color: #667eea;
+            font-weight: 600;
+            text-decoration: none;
+        }
+
         .test-results {
             display: grid;
             grid-template-columns: repeat(auto-fit, minmax(300px, 1fr));
@@ -534,7 +785,7 @@ const htmlTemplate = `<!DOCTYPE html>
         <div class="header">
             <h1>🏆 Load Test Results</h1>
             <div class="subtitle">Participant Rankings - Credsystem Hackathon 2025</div>
-            <div class="generated-at">Generated at: {{.GeneratedAt}}</div>
+            <div class="generated-at">Generated at: {{.GeneratedAt}}{{if .Live}} · <span id="live-status">🔴 live</span>{{end}}</div>
         </div>
 
         <div class="scoring-info">
@@ -552,6 +803,17 @@ const htmlTemplate = `<!DOCTYPE html>
                 <div class="criteria-item time">
                     <strong>⏱️ Time Penalty:</strong> -0.01 points per millisecond
                 </div>
+                <div class="criteria-item quality">
+                    <strong>🧪 Data Quality:</strong> result files failing validation are not scored
+                </div>
+                {{if .TieBreakers}}
+                <div class="criteria-item tiebreak">
+                    <strong>⚖️ Tie-breakers:</strong> {{range $i, $t := .TieBreakers}}{{if $i}}, then {{end}}{{$t}}{{end}}
+                </div>
+                {{end}}
+                <div class="criteria-item penalty">
+                    <strong>🚫 Disqualified:</strong> listed last, without a rank
+                </div>
             </div>
         </div>
 
@@ -566,49 +828,49 @@ const htmlTemplate = `<!DOCTYPE html>
                         <th>Total Failed</th>
                         <th>Avg Time (93)</th>
                         <th>Avg Time (80)</th>
+                        <th>P95</th>
                         <th>Final Score</th>
                     </tr>
                 </thead>
                 <tbody>
-                    {{range $index, $p := .Participants}}
-                    <tr>
+                    {{range $p := .Participants}}
+                    <tr{{if $p.Disqualified}} class="disqualified"{{end}}>
+                        <td>{{template "rank-badge" $p}}</td>
                         <td>
-                            {{if eq $index 0}}
-                                <span class="rank-badge rank-1">{{add $index 1}}</span>
-                            {{else if eq $index 1}}
-                                <span class="rank-badge rank-2">{{add $index 1}}</span>
-                            {{else if eq $index 2}}
-                                <span class="rank-badge rank-3">{{add $index 1}}</span>
-                            {{else}}
-                                <span class="rank-badge rank-other">{{add $index 1}}</span>
-                            {{end}}
+                            <span class="participant-name">{{if $p.DetailPage}}<a href="{{$p.DetailPage}}">{{$p.DisplayName}}</a>{{else}}{{$p.DisplayName}}{{end}}</span>
+                            {{range $p.Flags}}<span class="flag-badge{{if .Disqualified}} dq{{end}}" title="{{.Detail}}">{{if .Disqualified}}🚫 {{end}}{{.Label}}</span>{{end}}
+                            {{if $p.Issues}}<span class="quality-badge{{if $p.HasErrors}} error{{end}}" title="{{range $p.Issues}}{{.}}&#10;{{end}}">⚠️ data quality</span>{{end}}
+                            {{if $p.Team}}<div class="participant-meta">Team #{{$p.Team.Number}} · {{$p.Name}}</div>{{end}}
                         </td>
-                        <td><span class="participant-name">{{$p.Name}}</span></td>
                         <td><span class="metric success">{{$p.TotalSuccess}}</span></td>
                         <td><span class="metric failed">{{$p.TotalFailed}}</span></td>
                         <td>{{formatTime $p.AvgTime93}}</td>
                         <td>{{formatTime $p.AvgTime80}}</td>
+                        <td>{{formatTime $p.P95Ms}}</td>
                         <td><span class="score">{{printf "%.2f" $p.Score}}</span></td>
                     </tr>
                     {{end}}
                 </tbody>
             </table>
 
+            {{if .HardestPage}}
+            <p class="drill-down-link">🔎 <a href="{{.HardestPage}}">Hardest intents across all participants</a></p>
+            {{end}}
+
             <h2>📋 Detailed Breakdown</h2>
             <div class="details">
-                {{range $index, $p := .Participants}}
+                {{range $p := .Participants}}
                 <div class="participant-card">
                     <div class="participant-card-header">
-                        {{if eq $index 0}}
-                            <span class="rank-badge rank-1">{{add $index 1}}</span>
-                        {{else if eq $index 1}}
-                            <span class="rank-badge rank-2">{{add $index 1}}</span>
-                        {{else if eq $index 2}}
-                            <span class="rank-badge rank-3">{{add $index 1}}</span>
-                        {{else}}
-                            <span class="rank-badge rank-other">{{add $index 1}}</span>
-                        {{end}}
-                        <h3>{{$p.Name}}</h3>
+                        {{template "rank-badge" $p}}
+                        <div>
+                            <h3>{{$p.DisplayName}}</h3>
+                            {{if $p.Team}}
+                            <div class="participant-meta">Team #{{$p.Team.Number}} · {{$p.Name}}</div>
+                            {{if $p.Team.Members}}<div class="participant-meta">👥 {{range $i, $m := $p.Team.Members}}{{if $i}}, {{end}}{{$m}}{{end}}</div>{{end}}
+                            {{end}}
+                        </div>
+                        {{if $p.DetailPage}}<a class="card-detail-link" href="{{$p.DetailPage}}">View drill-down →</a>{{end}}
                     </div>
 
                     <div class="test-results">
@@ -691,6 +953,17 @@ const htmlTemplate = `<!DOCTYPE html>
                         {{end}}
                     </div>
 
+                    {{if $p.Issues}}
+                    <div class="quality-issues{{if $p.HasErrors}} error{{end}}">
+                        <h4>⚠️ Data Quality</h4>
+                        <ul>
+                            {{range $p.Issues}}
+                            <li class="{{.Severity}}"><strong>Test {{.Suite}}</strong> ({{.Severity}}): {{.Message}}</li>
+                            {{end}}
+                        </ul>
+                    </div>
+                    {{end}}
+
                     <div class="combined-score">
                         Combined Score: {{printf "%.2f" $p.Score}} points
                     </div>
@@ -699,9 +972,29 @@ const htmlTemplate = `<!DOCTYPE html>
             </div>
         </div>
     </div>
+    {{if .Live}}
+    <script>
+        const events = new EventSource("api/events");
+        events.addEventListener("ranking", () => window.location.reload());
+        events.onerror = () => {
+            document.getElementById("live-status").textContent = "⚪ reconnecting";
+        };
+    </script>
+    {{end}}
 </body>
 </html>`
 
+// rankBadgeTemplate renders the rank of a participant, highlighting the
+// podium and marking disqualified participants
+const rankBadgeTemplate = `{{define "rank-badge"}}
+{{- if .Disqualified}}<span class="rank-badge rank-dq">DQ</span>
+{{- else if eq .Rank 1}}<span class="rank-badge rank-1">1</span>
+{{- else if eq .Rank 2}}<span class="rank-badge rank-2">2</span>
+{{- else if eq .Rank 3}}<span class="rank-badge rank-3">3</span>
+{{- else}}<span class="rank-badge rank-other">{{.Rank}}</span>
+{{- end}}
+{{- end}}`
+
 func formatTime(ms float64) string {
 	if ms == 0 {
 		return "N/A"
@@ -0,0 +1,331 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// runnerReportFile is written by run.sh next to the result files
+	runnerReportFile = "runner.json"
+
+	// conformanceFile is written by run.sh next to the result files, after
+	// the scored runs
+	conformanceFile = "conformance.json"
+
+	// healthzErrorFile is written by run.sh in the participant's folder
+	// when the service never answered the healthz probe
+	healthzErrorFile = "error.logs"
+)
+
+// Flag codes, also accepted by the -disqualify flag
+const (
+	FlagMissingSuite = "missing-suite"
+	FlagHealthz      = "healthz"
+	FlagResources    = "resources"
+	FlagPenalty      = "penalty"
+	FlagConformance  = "conformance"
+)
+
+// tieBreakers compare two participants with the same score. They return a
+// negative number when a ranks above b, a positive one when b ranks above
+// a and zero when they are still tied.
+var tieBreakers = map[string]struct {
+	label   string
+	compare func(a, b *ParticipantResult) int
+}{
+	"failures": {
+		label: "fewer failures",
+		compare: func(a, b *ParticipantResult) int {
+			return a.TotalFailed - b.TotalFailed
+		},
+	},
+	"p95": {
+		label: "lower p95 latency",
+		compare: func(a, b *ParticipantResult) int {
+			return compareUnknownLast(a.P95Ms == 0, b.P95Ms == 0, func() int {
+				return cmp.Compare(a.P95Ms, b.P95Ms)
+			})
+		},
+	},
+	"submission": {
+		label: "earlier submission",
+		compare: func(a, b *ParticipantResult) int {
+			return compareUnknownLast(a.SubmittedAt.IsZero(), b.SubmittedAt.IsZero(), func() int {
+				return a.SubmittedAt.Compare(b.SubmittedAt)
+			})
+		},
+	},
+}
+
+// RunnerReport is what run.sh records about a participant's run beyond the
+// load test results themselves
+type RunnerReport struct {
+	SubmittedAt        time.Time `json:"submitted_at"`
+	HealthzOK          *bool     `json:"healthz_ok"`
+	ResourceViolations []string  `json:"resource_violations"`
+	Penalties          []struct {
+		Reason string  `json:"reason"`
+		Points float64 `json:"points"`
+	} `json:"penalties"`
+}
+
+// ConformanceResult is the outcome of one find-service contract check, as
+// written by cmd/conformance
+type ConformanceResult struct {
+	Check   string `json:"check"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+// RankingFlag is a disqualification or penalty shown as a badge next to the
+// participant
+type RankingFlag struct {
+	Code         string  `json:"code"`
+	Label        string  `json:"label"`
+	Detail       string  `json:"detail"`
+	Disqualified bool    `json:"disqualified"`
+	Points       float64 `json:"points,omitempty"`
+}
+
+// RankingOptions configures how participants are read and ordered
+type RankingOptions struct {
+	TieBreakers []string
+	Disqualify  []string
+	TeamsFile   string
+}
+
+// TieBreakerLabels describes the configured tie-breakers for the report
+func (o RankingOptions) TieBreakerLabels() []string {
+	labels := make([]string, len(o.TieBreakers))
+	for i, name := range o.TieBreakers {
+		labels[i] = tieBreakers[name].label
+	}
+	return labels
+}
+
+// registerRankingFlags adds the ranking flags to fs. The returned function
+// must be called after parsing to get the validated options.
+func registerRankingFlags(fs *flag.FlagSet) func() (RankingOptions, error) {
+	tieBreak := fs.String("tiebreak", "failures,p95,submission", "Comma separated tie-breakers applied in order when scores are equal (failures, p95, submission)")
+	teamsFile := fs.String("teams", "../../TIMES.md", "Path to the teams file with display names and members, empty to disable")
+	disqualify := fs.String("disqualify", "healthz,resources", "Comma separated flags that disqualify a participant instead of only showing a badge (missing-suite, healthz, resources, conformance)")
+
+	return func() (RankingOptions, error) {
+		opts := RankingOptions{TeamsFile: *teamsFile}
+
+		for _, name := range splitList(*tieBreak) {
+			if _, ok := tieBreakers[name]; !ok {
+				return opts, fmt.Errorf("unknown tie-breaker %q", name)
+			}
+			opts.TieBreakers = append(opts.TieBreakers, name)
+		}
+
+		for _, code := range splitList(*disqualify) {
+			if !slices.Contains([]string{FlagMissingSuite, FlagHealthz, FlagResources, FlagConformance}, code) {
+				return opts, fmt.Errorf("unknown disqualification flag %q", code)
+			}
+			opts.Disqualify = append(opts.Disqualify, code)
+		}
+
+		return opts, nil
+	}
+}
+
+func splitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// readRunnerReport reads the optional runner report of a participant. A
+// missing report is not an error.
+func readRunnerReport(participantPath string) (*RunnerReport, error) {
+	data, err := os.ReadFile(filepath.Join(participantPath, "results", runnerReportFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var report RunnerReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", runnerReportFile, err)
+	}
+
+	return &report, nil
+}
+
+// readConformance reads the optional contract check results of a
+// participant. Missing results are not an error.
+func readConformance(participantPath string) ([]ConformanceResult, error) {
+	data, err := os.ReadFile(filepath.Join(participantPath, "results", conformanceFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ConformanceResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", conformanceFile, err)
+	}
+
+	return results, nil
+}
+
+// evaluateFlags works out the disqualification and penalty flags of a
+// participant from its results, the runner report and the contract checks
+func evaluateFlags(p *ParticipantResult, participantPath string, runner *RunnerReport, conformance []ConformanceResult, opts RankingOptions) []RankingFlag {
+	var flags []RankingFlag
+
+	add := func(code, label, detail string) {
+		flags = append(flags, RankingFlag{
+			Code:         code,
+			Label:        label,
+			Detail:       detail,
+			Disqualified: slices.Contains(opts.Disqualify, code),
+		})
+	}
+
+	for _, run := range []SuiteRun{{Suite: "93", Result: p.Test93}, {Suite: "80", Result: p.Test80}} {
+		if run.Result == nil || p.discarded(run.Suite) {
+			add(FlagMissingSuite, "missing "+run.Suite, fmt.Sprintf("no usable result file for test %s", run.Suite))
+		}
+	}
+
+	if detail := healthzFailure(participantPath, runner); detail != "" {
+		add(FlagHealthz, "healthz", detail)
+	}
+
+	var failedChecks []string
+	for _, r := range conformance {
+		if !r.Passed {
+			failedChecks = append(failedChecks, fmt.Sprintf("%s: %s", r.Check, r.Message))
+		}
+	}
+	if len(failedChecks) > 0 {
+		add(FlagConformance, fmt.Sprintf("contract %d/%d", len(conformance)-len(failedChecks), len(conformance)),
+			"failed contract checks: "+strings.Join(failedChecks, "; "))
+	}
+
+	if runner == nil {
+		return flags
+	}
+
+	for _, violation := range runner.ResourceViolations {
+		add(FlagResources, "resources", violation)
+	}
+
+	for _, penalty := range runner.Penalties {
+		flags = append(flags, RankingFlag{
+			Code:   FlagPenalty,
+			Label:  fmt.Sprintf("-%.0f pts", penalty.Points),
+			Detail: penalty.Reason,
+			Points: penalty.Points,
+		})
+	}
+
+	return flags
+}
+
+// healthzFailure describes why the service of a participant is considered
+// down, or returns an empty string when it answered the healthz probe. The
+// runner report of the current run decides when it records the outcome;
+// error.logs only counts without it, since one left over from an earlier
+// run would otherwise outlive a successful run.
+func healthzFailure(participantPath string, runner *RunnerReport) string {
+	if runner != nil && runner.HealthzOK != nil {
+		if *runner.HealthzOK {
+			return ""
+		}
+		return "the service never answered GET /api/healthz"
+	}
+
+	if _, err := os.Stat(filepath.Join(participantPath, healthzErrorFile)); err == nil {
+		return "run.sh recorded a healthz failure in " + healthzErrorFile
+	}
+
+	return ""
+}
+
+// sortParticipants orders participants by score, breaking ties with the
+// configured tie-breakers and then by name so the order is deterministic.
+// Disqualified participants always go last and get no rank.
+func sortParticipants(participants []ParticipantResult, opts RankingOptions) {
+	sort.SliceStable(participants, func(i, j int) bool {
+		a, b := &participants[i], &participants[j]
+
+		if a.Disqualified() != b.Disqualified() {
+			return b.Disqualified()
+		}
+
+		// compare scores as displayed, so two participants shown with the
+		// same score are treated as tied
+		if sa, sb := math.Round(a.Score*100), math.Round(b.Score*100); sa != sb {
+			return sa > sb
+		}
+
+		for _, name := range opts.TieBreakers {
+			if c := tieBreakers[name].compare(a, b); c != 0 {
+				return c < 0
+			}
+		}
+
+		return a.Name < b.Name
+	})
+
+	rank := 0
+	for i := range participants {
+		if participants[i].Disqualified() {
+			participants[i].Rank = 0
+			continue
+		}
+		rank++
+		participants[i].Rank = rank
+	}
+}
+
+// percentile returns the nearest-rank percentile of values, or 0 when there
+// are none
+func percentile(values []int64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	idx := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	idx = max(0, min(idx, len(sorted)-1))
+
+	return float64(sorted[idx])
+}
+
+func compareUnknownLast(aUnknown, bUnknown bool, compare func() int) int {
+	switch {
+	case aUnknown && bUnknown:
+		return 0
+	case aUnknown:
+		return 1
+	case bUnknown:
+		return -1
+	}
+	return compare()
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSortParticipants(t *testing.T) {
+	submitted := time.Date(2025, 10, 25, 17, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		opts         RankingOptions
+		participants []ParticipantResult
+		wantOrder    []string
+		wantRanks    []int
+	}{
+		{
+			name: "score decides",
+			opts: RankingOptions{TieBreakers: []string{"failures", "p95", "submission"}},
+			participants: []ParticipantResult{
+				{Name: "low", Score: 100},
+				{Name: "high", Score: 200},
+			},
+			wantOrder: []string{"high", "low"},
+			wantRanks: []int{1, 2},
+		},
+		{
+			name: "fewer failures breaks tie",
+			opts: RankingOptions{TieBreakers: []string{"failures", "p95", "submission"}},
+			participants: []ParticipantResult{
+				{Name: "a", Score: 100, TotalFailed: 3, P95Ms: 100},
+				{Name: "b", Score: 100.001, TotalFailed: 1, P95Ms: 900},
+			},
+			wantOrder: []string{"b", "a"},
+			wantRanks: []int{1, 2},
+		},
+		{
+			name: "lower p95 breaks tie, unknown last",
+			opts: RankingOptions{TieBreakers: []string{"failures", "p95", "submission"}},
+			participants: []ParticipantResult{
+				{Name: "unknown", Score: 100},
+				{Name: "slow", Score: 100, P95Ms: 900},
+				{Name: "fast", Score: 100, P95Ms: 100},
+			},
+			wantOrder: []string{"fast", "slow", "unknown"},
+			wantRanks: []int{1, 2, 3},
+		},
+		{
+			name: "earlier submission breaks tie",
+			opts: RankingOptions{TieBreakers: []string{"submission"}},
+			participants: []ParticipantResult{
+				{Name: "late", Score: 100, SubmittedAt: submitted.Add(time.Hour)},
+				{Name: "early", Score: 100, SubmittedAt: submitted},
+			},
+			wantOrder: []string{"early", "late"},
+			wantRanks: []int{1, 2},
+		},
+		{
+			name: "tie-breaker order is configurable",
+			opts: RankingOptions{TieBreakers: []string{"p95", "failures"}},
+			participants: []ParticipantResult{
+				{Name: "a", Score: 100, TotalFailed: 1, P95Ms: 900},
+				{Name: "b", Score: 100, TotalFailed: 3, P95Ms: 100},
+			},
+			wantOrder: []string{"b", "a"},
+			wantRanks: []int{1, 2},
+		},
+		{
+			name: "name keeps full ties deterministic",
+			opts: RankingOptions{},
+			participants: []ParticipantResult{
+				{Name: "zeta", Score: 100},
+				{Name: "alpha", Score: 100},
+			},
+			wantOrder: []string{"alpha", "zeta"},
+			wantRanks: []int{1, 2},
+		},
+		{
+			name: "disqualified go last without rank",
+			opts: RankingOptions{TieBreakers: []string{"failures"}},
+			participants: []ParticipantResult{
+				{Name: "dq", Score: 1000, Flags: []RankingFlag{{Code: FlagHealthz, Disqualified: true}}},
+				{Name: "flagged", Score: 10, Flags: []RankingFlag{{Code: FlagMissingSuite}}},
+				{Name: "ok", Score: 500},
+			},
+			wantOrder: []string{"ok", "flagged", "dq"},
+			wantRanks: []int{1, 2, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sortParticipants(tt.participants, tt.opts)
+
+			for i, p := range tt.participants {
+				if p.Name != tt.wantOrder[i] {
+					t.Fatalf("expected position %d to be %s, got %s", i, tt.wantOrder[i], p.Name)
+				}
+				if p.Rank != tt.wantRanks[i] {
+					t.Errorf("expected %s to have rank %d, got %d", p.Name, tt.wantRanks[i], p.Rank)
+				}
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int64
+		p      float64
+		want   float64
+	}{
+		{name: "empty", values: nil, p: 95, want: 0},
+		{name: "single", values: []int64{42}, p: 95, want: 42},
+		{name: "nearest rank", values: []int64{50, 10, 40, 20, 30, 60, 70, 80, 90, 100}, p: 95, want: 100},
+		{name: "median", values: []int64{30, 10, 20}, p: 50, want: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.values, tt.p); got != tt.want {
+				t.Errorf("percentile(%v, %v) = %v, want %v", tt.values, tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateFlags_Conformance(t *testing.T) {
+	participantPath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(participantPath, "results"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	data := `[
+  {"check": "healthz", "passed": true},
+  {"check": "post-only", "passed": false, "message": "GET returned 200, expected 405"},
+  {"check": "empty-intent", "passed": true}
+]`
+	if err := os.WriteFile(filepath.Join(participantPath, "results", conformanceFile), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	conformance, err := readConformance(participantPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := &ParticipantResult{Test93: &TestResult{}, Test80: &TestResult{}}
+
+	tests := []struct {
+		name             string
+		opts             RankingOptions
+		wantDisqualified bool
+	}{
+		{name: "badge only", opts: RankingOptions{}},
+		{name: "disqualifying", opts: RankingOptions{Disqualify: []string{FlagConformance}}, wantDisqualified: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := evaluateFlags(p, participantPath, nil, conformance, tt.opts)
+			if len(flags) != 1 {
+				t.Fatalf("expected 1 flag, got %+v", flags)
+			}
+
+			f := flags[0]
+			if f.Code != FlagConformance || f.Label != "contract 2/3" || !strings.Contains(f.Detail, "post-only") {
+				t.Errorf("unexpected flag %+v", f)
+			}
+			if f.Disqualified != tt.wantDisqualified {
+				t.Errorf("expected disqualified %v, got %v", tt.wantDisqualified, f.Disqualified)
+			}
+		})
+	}
+
+	if flags := evaluateFlags(p, t.TempDir(), nil, nil, RankingOptions{}); len(flags) != 0 {
+		t.Errorf("expected no flag without contract results, got %+v", flags)
+	}
+}
+
+func TestCalculateScore_DiscardedSuite(t *testing.T) {
+	valid93, issues := validateTestResult("93", []byte(validResult))
+	if hasErrors(issues) {
+		t.Fatalf("unexpected issues: %v", issues)
+	}
+
+	// half of the extra intents failed, slowly
+	failing80 := &TestResult{TotalRequests: 80, TotalSuccess: 40, TotalFailed: 40, AverageTime: "15000ms"}
+	malformed80 := []ValidationIssue{{Suite: "80", Severity: SeverityError, Message: "invalid JSON"}}
+
+	score := func(result80 *TestResult, issues80 []ValidationIssue) *ParticipantResult {
+		p := &ParticipantResult{}
+		p.AvgTime93 = p.addSuite("93", valid93, nil)
+		p.AvgTime80 = p.addSuite("80", result80, issues80)
+		p.Score = calculateScore(p)
+		return p
+	}
+
+	failing := score(failing80, nil)
+	malformed := score(&TestResult{TotalSuccess: 80}, malformed80)
+	missing := score(nil, nil)
+
+	for name, p := range map[string]*ParticipantResult{"malformed": malformed, "missing": missing} {
+		if p.TotalFailed != valid93.TotalFailed+80 || p.AvgTime80 != clientTimeoutMs {
+			t.Errorf("%s: expected the 80 suite to count as failed at the timeout, got %d failed in %vms", name, p.TotalFailed, p.AvgTime80)
+		}
+		if p.Score >= failing.Score {
+			t.Errorf("%s: expected score %.2f below the failing run's %.2f", name, p.Score, failing.Score)
+		}
+	}
+}
+
+func TestHealthzFailure(t *testing.T) {
+	stale := t.TempDir()
+	if err := os.WriteFile(filepath.Join(stale, healthzErrorFile), []byte("no answer"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ok, failed := true, false
+
+	tests := []struct {
+		name            string
+		participantPath string
+		runner          *RunnerReport
+		wantFailed      bool
+	}{
+		{name: "nothing recorded", participantPath: t.TempDir()},
+		{name: "error.logs without runner report", participantPath: stale, wantFailed: true},
+		{name: "error.logs without healthz outcome", participantPath: stale, runner: &RunnerReport{}, wantFailed: true},
+		{name: "stale error.logs after a healthy run", participantPath: stale, runner: &RunnerReport{HealthzOK: &ok}},
+		{name: "runner report failure", participantPath: t.TempDir(), runner: &RunnerReport{HealthzOK: &failed}, wantFailed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := healthzFailure(tt.participantPath, tt.runner) != ""; got != tt.wantFailed {
+				t.Errorf("expected failed %v, got %v", tt.wantFailed, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	leaderboardPage  = "index.html"
+	leaderboardDir   = "details"
+	eventKeepAlive   = 30 * time.Second
+	shutdownDeadline = 5 * time.Second
+)
+
+// RankingSnapshot is the ranking computed from the results on disk at a
+// given moment
+type RankingSnapshot struct {
+	GeneratedAt  string              `json:"generated_at"`
+	Participants []ParticipantResult `json:"participants"`
+}
+
+// leaderboard keeps the rendered site and ranking in memory and notifies
+// event stream subscribers whenever the results on disk change
+type leaderboard struct {
+	basePath string
+	opts     RankingOptions
+
+	mu          sync.RWMutex
+	pages       memoryPages
+	snapshot    RankingSnapshot
+	fingerprint string
+
+	subsMu      sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+// runServe implements the serve subcommand: it watches the participants'
+// results directories and serves an always up to date leaderboard
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	participantesPath := fs.String("path", "../../participantes", "Path to participantes folder")
+	addr := fs.String("addr", ":8080", "Address to listen on")
+	interval := fs.Duration("interval", 2*time.Second, "How often to check the results directories for changes")
+	rankingOptions := registerRankingFlags(fs)
+	fs.Parse(args)
+
+	opts, err := rankingOptions()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(*participantesPath); os.IsNotExist(err) {
+		return fmt.Errorf("path '%s' does not exist", *participantesPath)
+	}
+
+	lb := newLeaderboard(*participantesPath, opts)
+	if _, err := lb.refresh(); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go lb.watch(ctx, *interval)
+
+	srv := &http.Server{
+		Addr:    *addr,
+		Handler: lb.routes(),
+		// cancelling the base context ends the open event streams, otherwise
+		// Shutdown would wait for them forever
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownDeadline)
+		defer cancel()
+
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("🚀 Serving live leaderboard on %s (watching %s)\n", *addr, *participantesPath)
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+func newLeaderboard(basePath string, opts RankingOptions) *leaderboard {
+	return &leaderboard{
+		basePath:    basePath,
+		opts:        opts,
+		pages:       memoryPages{},
+		subscribers: make(map[chan struct{}]struct{}),
+	}
+}
+
+// watch polls the results directories until ctx is done, rebuilding the
+// leaderboard whenever a result file is added, removed or modified
+func (lb *leaderboard) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := lb.refresh()
+			if err != nil {
+				fmt.Printf("Error refreshing leaderboard: %v\n", err)
+				continue
+			}
+
+			if changed {
+				fmt.Printf("🔄 Results changed, leaderboard rebuilt at %s\n", time.Now().Format("15:04:05"))
+				lb.broadcast()
+			}
+		}
+	}
+}
+
+// refresh rebuilds the leaderboard if the results on disk changed since
+// the last call. A failed rebuild keeps serving the previous one.
+func (lb *leaderboard) refresh() (bool, error) {
+	fingerprint, err := resultsFingerprint(lb.basePath, lb.opts.TeamsFile)
+	if err != nil {
+		return false, err
+	}
+
+	lb.mu.RLock()
+	unchanged := fingerprint == lb.fingerprint
+	lb.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	participants, err := loadRanking(lb.basePath, lb.opts)
+	if err != nil {
+		return false, fmt.Errorf("failed to read participants: %w", err)
+	}
+
+	pages := memoryPages{}
+
+	if err := generateDetailPages(pages, participants, leaderboardPage, leaderboardDir); err != nil {
+		return false, fmt.Errorf("failed to generate detail pages: %w", err)
+	}
+
+	if err := generateHTMLReport(pages, participants, lb.opts, leaderboardPage, true); err != nil {
+		return false, fmt.Errorf("failed to generate HTML report: %w", err)
+	}
+
+	snapshot := RankingSnapshot{
+		GeneratedAt:  time.Now().Format(time.RFC3339),
+		Participants: participants,
+	}
+
+	lb.mu.Lock()
+	lb.pages = pages
+	lb.snapshot = snapshot
+	lb.fingerprint = fingerprint
+	lb.mu.Unlock()
+
+	return true, nil
+}
+
+// resultsFingerprint summarizes name, size and modification time of every
+// result file, healthz failure log and extra file (such as the teams file)
+// so that any change to them yields a different value
+func resultsFingerprint(basePath string, extra ...string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(basePath, "*", "results", "*.json"))
+	if err != nil {
+		return "", err
+	}
+
+	healthzLogs, err := filepath.Glob(filepath.Join(basePath, "*", healthzErrorFile))
+	if err != nil {
+		return "", err
+	}
+
+	files = append(files, healthzLogs...)
+	sort.Strings(files)
+
+	for _, file := range extra {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+
+	var sb strings.Builder
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			// the file may have been removed between Glob and Stat, which
+			// the next poll will pick up anyway
+			continue
+		}
+
+		fmt.Fprintf(&sb, "%s|%d|%d\n", file, info.Size(), info.ModTime().UnixNano())
+	}
+
+	return sb.String(), nil
+}
+
+func (lb *leaderboard) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/ranking", lb.handleRanking)
+	mux.HandleFunc("GET /api/events", lb.handleEvents)
+	mux.HandleFunc("GET /", lb.handlePage)
+	return mux
+}
+
+func (lb *leaderboard) handleRanking(w http.ResponseWriter, r *http.Request) {
+	lb.mu.RLock()
+	snapshot := lb.snapshot
+	lb.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(snapshot)
+}
+
+func (lb *leaderboard) handlePage(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	if name == "" {
+		name = leaderboardPage
+	}
+
+	lb.mu.RLock()
+	page, ok := lb.pages[name]
+	lb.mu.RUnlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(page)
+}
+
+// handleEvents streams a "ranking" server-sent event every time the
+// leaderboard is rebuilt
+func (lb *leaderboard) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	updates := lb.subscribe()
+	defer lb.unsubscribe(updates)
+
+	fmt.Fprint(w, "retry: 2000\n\n")
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-updates:
+			lb.mu.RLock()
+			generatedAt := lb.snapshot.GeneratedAt
+			lb.mu.RUnlock()
+
+			fmt.Fprintf(w, "event: ranking\ndata: {\"generated_at\":%q}\n\n", generatedAt)
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		}
+
+		flusher.Flush()
+	}
+}
+
+func (lb *leaderboard) subscribe() chan struct{} {
+	ch := make(chan struct{}, 1)
+
+	lb.subsMu.Lock()
+	lb.subscribers[ch] = struct{}{}
+	lb.subsMu.Unlock()
+
+	return ch
+}
+
+func (lb *leaderboard) unsubscribe(ch chan struct{}) {
+	lb.subsMu.Lock()
+	delete(lb.subscribers, ch)
+	lb.subsMu.Unlock()
+}
+
+// broadcast notifies every subscriber without blocking; a subscriber that
+// has not consumed the previous notification yet gets a single one
+func (lb *leaderboard) broadcast() {
+	lb.subsMu.Lock()
+	defer lb.subsMu.Unlock()
+
+	for ch := range lb.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResultsFingerprint(t *testing.T) {
+	basePath := t.TempDir()
+	resultFile := writeResult(t, basePath, "team", "93.json", validResult)
+
+	before, err := resultsFingerprint(basePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	again, _ := resultsFingerprint(basePath)
+	if again != before {
+		t.Error("expected the same fingerprint while nothing changed")
+	}
+
+	if err := os.WriteFile(resultFile, []byte(validResult+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if after, _ := resultsFingerprint(basePath); after == before {
+		t.Error("expected a modified result file to change the fingerprint")
+	}
+
+	before, _ = resultsFingerprint(basePath)
+	writeResult(t, basePath, "other", "80.json", "{}")
+	if after, _ := resultsFingerprint(basePath); after == before {
+		t.Error("expected a new result file to change the fingerprint")
+	}
+}
+
+func TestLeaderboardEvents(t *testing.T) {
+	basePath := t.TempDir()
+	resultFile := writeResult(t, basePath, "team", "93.json", validResult)
+
+	lb := newLeaderboard(basePath, RankingOptions{})
+	if changed, err := lb.refresh(); err != nil || !changed {
+		t.Fatalf("expected the first refresh to build the leaderboard, got %v, %v", changed, err)
+	}
+	if changed, _ := lb.refresh(); changed {
+		t.Error("expected no rebuild while nothing changed")
+	}
+
+	first := lb.snapshot.Participants[0].TotalSuccess
+
+	srv := httptest.NewServer(lb.routes())
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected Content-Type text/event-stream, got %s", ct)
+	}
+
+	lines := bufio.NewScanner(resp.Body)
+	next := func() string {
+		t.Helper()
+		if !lines.Scan() {
+			t.Fatalf("event stream ended: %v", lines.Err())
+		}
+		return lines.Text()
+	}
+
+	// the retry line is written once the stream is subscribed
+	if line := next(); !strings.HasPrefix(line, "retry:") {
+		t.Fatalf("expected the retry line first, got %q", line)
+	}
+
+	go lb.watch(ctx, 10*time.Millisecond)
+
+	changed := strings.NewReplacer(
+		`"total_success": 90`, `"total_success": 91`,
+		`"total_failed": 3`, `"total_failed": 2`,
+		`"success_rate": 96.7741935483871`, `"success_rate": 97.84946236559139`,
+		`"failure_rate": 3.225806451612903`, `"failure_rate": 2.150537634408602`,
+	).Replace(validResult)
+	if err := os.WriteFile(resultFile, []byte(changed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for line := next(); line != "event: ranking"; line = next() {
+	}
+	if data := next(); !strings.HasPrefix(data, "data: {\"generated_at\":") {
+		t.Errorf("expected the event data to carry generated_at, got %q", data)
+	}
+
+	lb.mu.RLock()
+	got := lb.snapshot.Participants[0].TotalSuccess
+	lb.mu.RUnlock()
+
+	if got == first {
+		t.Errorf("expected the ranking to reflect the new result, still %d successes", got)
+	}
+}
+
+func writeResult(t *testing.T, basePath, participant, name, data string) string {
+	t.Helper()
+
+	dir := filepath.Join(basePath, participant, "results")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
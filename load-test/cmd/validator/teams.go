@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Team is a participant team as listed in TIMES.md
+type Team struct {
+	Name    string   `json:"name"`
+	Number  int      `json:"number"`
+	Members []string `json:"members"`
+}
+
+// accentReplacer folds the Portuguese accented letters found in team names
+// so they can be matched against directory names
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// teamSlug turns a team name or directory name into the form used to match
+// one against the other, e.g. "Esquadrão do Scheduler" and
+// "esquadrao-do-scheduler" both become "esquadrao-do-scheduler"
+func teamSlug(name string) string {
+	name = accentReplacer.Replace(strings.ToLower(name))
+	return strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}), "-")
+}
+
+// loadTeams reads the teams file and matches it against the participant
+// directories, printing a warning for every mismatch. It returns nil when
+// there is no usable teams file.
+func loadTeams(path string, dirs []string) map[string]*Team {
+	if path == "" {
+		return nil
+	}
+
+	teams, err := readTeams(path)
+	if err != nil {
+		fmt.Printf("Warning: could not read teams file: %v\n", err)
+		return nil
+	}
+
+	matched, warnings := matchTeams(teams, dirs)
+	for _, warning := range warnings {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+
+	return matched
+}
+
+// readTeams reads the teams file, usually TIMES.md
+func readTeams(path string) ([]Team, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseTeams(file)
+}
+
+// parseTeams parses a markdown roster where every team is a "## Name - N"
+// heading followed by a bullet list of members
+func parseTeams(r io.Reader) ([]Team, error) {
+	var teams []Team
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if heading, ok := strings.CutPrefix(line, "## "); ok {
+			team := Team{Name: strings.TrimSpace(heading)}
+
+			if idx := strings.LastIndex(team.Name, " - "); idx >= 0 {
+				if number, err := strconv.Atoi(strings.TrimSpace(team.Name[idx+3:])); err == nil {
+					team.Name = strings.TrimSpace(team.Name[:idx])
+					team.Number = number
+				}
+			}
+
+			teams = append(teams, team)
+			continue
+		}
+
+		if member, ok := strings.CutPrefix(line, "- "); ok && len(teams) > 0 {
+			current := &teams[len(teams)-1]
+			current.Members = append(current.Members, strings.TrimSpace(member))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read teams: %w", err)
+	}
+
+	return teams, nil
+}
+
+// matchTeams maps every directory to its team and reports, as warnings,
+// directories without a team and teams without a directory
+func matchTeams(teams []Team, dirs []string) (map[string]*Team, []string) {
+	bySlug := make(map[string]*Team, len(teams))
+	for i := range teams {
+		bySlug[teamSlug(teams[i].Name)] = &teams[i]
+	}
+
+	matched := make(map[string]*Team, len(dirs))
+	used := make(map[*Team]bool, len(teams))
+
+	var warnings []string
+
+	for _, dir := range dirs {
+		team, ok := bySlug[teamSlug(dir)]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("directory '%s' has no entry in the teams file", dir))
+			continue
+		}
+
+		matched[dir] = team
+		used[team] = true
+	}
+
+	for i := range teams {
+		if !used[&teams[i]] {
+			warnings = append(warnings, fmt.Sprintf("team '%s' (%d) has no directory in participantes", teams[i].Name, teams[i].Number))
+		}
+	}
+
+	sort.Strings(warnings)
+
+	return matched, warnings
+}
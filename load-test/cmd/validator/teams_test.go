@@ -0,0 +1,95 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleTeams = `# Times
+
+## Galáxia dos Gophers - 1
+
+- Willian Rodrigues Chan
+- Verônica Freitas Santos
+
+## Cowboys do Contexto - 10
+
+- Ari Tedeschi Junior
+
+## Esquadrão do Scheduler - 18
+
+## Time Sem Número
+
+- Fulano de Tal
+`
+
+func TestParseTeams(t *testing.T) {
+	teams, err := parseTeams(strings.NewReader(sampleTeams))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []Team{
+		{Name: "Galáxia dos Gophers", Number: 1, Members: []string{"Willian Rodrigues Chan", "Verônica Freitas Santos"}},
+		{Name: "Cowboys do Contexto", Number: 10, Members: []string{"Ari Tedeschi Junior"}},
+		{Name: "Esquadrão do Scheduler", Number: 18},
+		{Name: "Time Sem Número", Members: []string{"Fulano de Tal"}},
+	}
+
+	if !reflect.DeepEqual(teams, want) {
+		t.Errorf("expected %+v, got %+v", want, teams)
+	}
+}
+
+func TestTeamSlug(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "Galáxia dos Gophers", want: "galaxia-dos-gophers"},
+		{input: "Cowboys-do-Contexto", want: "cowboys-do-contexto"},
+		{input: "Caçadores de Corrida", want: "cacadores-de-corrida"},
+		{input: "Trovões da Taxa", want: "trovoes-da-taxa"},
+		{input: "  Heróis  da Pilha ", want: "herois-da-pilha"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := teamSlug(tt.input); got != tt.want {
+				t.Errorf("teamSlug(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchTeams(t *testing.T) {
+	teams, err := parseTeams(strings.NewReader(sampleTeams))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	matched, warnings := matchTeams(teams, []string{"galaxia-dos-gophers", "Cowboys-do-Contexto", "time-fantasma"})
+
+	if got := matched["galaxia-dos-gophers"]; got == nil || got.Number != 1 {
+		t.Errorf("expected galaxia-dos-gophers to match team 1, got %+v", got)
+	}
+
+	if got := matched["Cowboys-do-Contexto"]; got == nil || got.Number != 10 {
+		t.Errorf("expected Cowboys-do-Contexto to match team 10, got %+v", got)
+	}
+
+	if _, ok := matched["time-fantasma"]; ok {
+		t.Error("expected time-fantasma to have no team")
+	}
+
+	wantWarnings := []string{
+		"directory 'time-fantasma' has no entry in the teams file",
+		"team 'Esquadrão do Scheduler' (18) has no directory in participantes",
+		"team 'Time Sem Número' (0) has no directory in participantes",
+	}
+
+	if !reflect.DeepEqual(warnings, wantWarnings) {
+		t.Errorf("expected warnings %q, got %q", wantWarnings, warnings)
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// resultSchemaVersion is the result file format written by the load test.
+// Files without a schema_version predate it and are accepted with a warning.
+const resultSchemaVersion = 1
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+
+	// rateTolerance is how far, in percentage points, a reported rate may
+	// drift from the one computed from the totals
+	rateTolerance = 0.1
+
+	// clientTimeoutMs is the load test client timeout, the worst latency a
+	// request can have
+	clientTimeoutMs = 20000
+
+	// maxPlausibleLatencyMs is the load test client timeout plus some slack;
+	// nothing slower can be reported by a genuine run
+	maxPlausibleLatencyMs = clientTimeoutMs + 1000
+)
+
+// requiredResultFields are the keys every result file must carry
+var requiredResultFields = []string{
+	"total_requests",
+	"timestamp",
+	"elapsed_time",
+	"total_success",
+	"total_failed",
+	"success_rate",
+	"failure_rate",
+	"fastest_time",
+	"slowest_time",
+	"average_time",
+}
+
+// knownResultFields are the keys the validator understands; anything else
+// is reported as a warning
+var knownResultFields = append([]string{"schema_version", "requests"}, requiredResultFields...)
+
+// expectedSuiteSizes is the number of intents in each suite's CSV
+var expectedSuiteSizes = map[string]int{
+	"93": 93,
+	"80": 80,
+}
+
+// ValidationIssue is a problem found in a participant's result file
+type ValidationIssue struct {
+	Suite    string `json:"suite"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func (i ValidationIssue) String() string {
+	return fmt.Sprintf("[%s] test %s: %s", i.Severity, i.Suite, i.Message)
+}
+
+// hasErrors reports whether any of the issues is severe enough to discard
+// the result file from scoring
+func hasErrors(issues []ValidationIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTimeMs extracts milliseconds from time strings like "3938ms"
+func parseTimeMs(timeStr string) (float64, error) {
+	trimmed := strings.TrimSpace(timeStr)
+
+	value, ok := strings.CutSuffix(trimmed, "ms")
+	if !ok {
+		return 0, fmt.Errorf("time %q is not in the NNNms format", timeStr)
+	}
+
+	val, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(val) || math.IsInf(val, 0) {
+		return 0, fmt.Errorf("time %q is not a valid number of milliseconds", timeStr)
+	}
+
+	if val < 0 {
+		return 0, fmt.Errorf("time %q is negative", timeStr)
+	}
+
+	return val, nil
+}
+
+// validateTestResult decodes a result file and checks it against the
+// result schema. A nil result means the file could not be decoded at all.
+func validateTestResult(suite string, data []byte) (*TestResult, []ValidationIssue) {
+	var issues []ValidationIssue
+
+	report := func(severity, format string, args ...any) {
+		issues = append(issues, ValidationIssue{
+			Suite:    suite,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		report(SeverityError, "invalid JSON: %v", err)
+		return nil, issues
+	}
+
+	var result TestResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			report(SeverityError, "field %q has the wrong type: expected %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value)
+		} else {
+			report(SeverityError, "failed to decode result: %v", err)
+		}
+		return nil, issues
+	}
+
+	// schema
+	switch _, ok := fields["schema_version"]; {
+	case !ok:
+		report(SeverityWarning, "no schema_version, assuming a legacy result file")
+	case result.SchemaVersion < 1 || result.SchemaVersion > resultSchemaVersion:
+		report(SeverityError, "unsupported schema_version %d (expected up to %d)", result.SchemaVersion, resultSchemaVersion)
+	}
+
+	for _, field := range requiredResultFields {
+		if _, ok := fields[field]; !ok {
+			report(SeverityError, "missing required field %q", field)
+		}
+	}
+
+	var unknown []string
+	for field := range fields {
+		if !slices.Contains(knownResultFields, field) {
+			unknown = append(unknown, field)
+		}
+	}
+	sort.Strings(unknown)
+	for _, field := range unknown {
+		report(SeverityWarning, "unknown field %q", field)
+	}
+
+	// totals
+	if result.TotalRequests < 0 || result.TotalSuccess < 0 || result.TotalFailed < 0 {
+		report(SeverityError, "negative totals (total=%d, success=%d, failed=%d)", result.TotalRequests, result.TotalSuccess, result.TotalFailed)
+	}
+
+	if result.TotalSuccess+result.TotalFailed != result.TotalRequests {
+		report(SeverityError, "inconsistent totals: success (%d) + failed (%d) != total (%d)", result.TotalSuccess, result.TotalFailed, result.TotalRequests)
+	}
+
+	if expected, ok := expectedSuiteSizes[suite]; ok && result.TotalRequests != expected {
+		report(SeverityWarning, "suspicious total_requests %d, the suite has %d intents", result.TotalRequests, expected)
+	}
+
+	if result.TotalRequests > 0 {
+		successRate := float64(result.TotalSuccess) / float64(result.TotalRequests) * 100
+		if math.Abs(result.SuccessRate-successRate) > rateTolerance {
+			report(SeverityWarning, "success_rate %.2f does not match the totals (%.2f)", result.SuccessRate, successRate)
+		}
+
+		failureRate := float64(result.TotalFailed) / float64(result.TotalRequests) * 100
+		if math.Abs(result.FailureRate-failureRate) > rateTolerance {
+			report(SeverityWarning, "failure_rate %.2f does not match the totals (%.2f)", result.FailureRate, failureRate)
+		}
+	}
+
+	// times
+	times := make(map[string]float64, 3)
+	for _, t := range []struct{ field, value string }{
+		{"fastest_time", result.FastestTime},
+		{"slowest_time", result.SlowestTime},
+		{"average_time", result.AverageTime},
+	} {
+		if _, ok := fields[t.field]; !ok {
+			continue
+		}
+
+		ms, err := parseTimeMs(t.value)
+		if err != nil {
+			report(SeverityError, "%s: %v", t.field, err)
+			continue
+		}
+
+		times[t.field] = ms
+	}
+
+	fastest, okFastest := times["fastest_time"]
+	slowest, okSlowest := times["slowest_time"]
+	average, okAverage := times["average_time"]
+
+	if okFastest && okSlowest && okAverage && (average < fastest || average > slowest) {
+		report(SeverityError, "average_time %.0fms is outside the fastest/slowest range (%.0fms-%.0fms)", average, fastest, slowest)
+	}
+
+	if okAverage && average == 0 && result.TotalRequests > 0 {
+		report(SeverityWarning, "suspicious average_time of 0ms for %d requests", result.TotalRequests)
+	}
+
+	if okSlowest && slowest > maxPlausibleLatencyMs {
+		report(SeverityWarning, "suspicious slowest_time %.0fms, above the load test client timeout", slowest)
+	}
+
+	if _, ok := fields["timestamp"]; ok {
+		if _, err := time.Parse(time.RFC3339, result.Timestamp); err != nil {
+			report(SeverityWarning, "timestamp %q is not RFC 3339", result.Timestamp)
+		}
+	}
+
+	// per-request records, when present, must agree with the totals
+	if len(result.Requests) > 0 {
+		if len(result.Requests) != result.TotalRequests {
+			report(SeverityError, "%d request records for %d total requests", len(result.Requests), result.TotalRequests)
+		}
+
+		succeeded := 0
+		for _, req := range result.Requests {
+			if req.Success {
+				succeeded++
+			}
+		}
+
+		if succeeded != result.TotalSuccess {
+			report(SeverityError, "%d successful request records for total_success %d", succeeded, result.TotalSuccess)
+		}
+	}
+
+	return &result, issues
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTimeMs(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{name: "integer", input: "3938ms", want: 3938},
+		{name: "decimal", input: "12.5ms", want: 12.5},
+		{name: "surrounding spaces", input: " 42ms ", want: 42},
+		{name: "zero", input: "0ms", want: 0},
+		{name: "empty", input: "", wantErr: true},
+		{name: "missing unit", input: "3938", wantErr: true},
+		{name: "seconds", input: "3.9s", wantErr: true},
+		{name: "not a number", input: "fastms", wantErr: true},
+		{name: "negative", input: "-10ms", wantErr: true},
+		{name: "NaN", input: "NaNms", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTimeMs(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTimeMs(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseTimeMs(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+const validResult = `{
+  "schema_version": 1,
+  "total_requests": 93,
+  "timestamp": "2025-10-25T15:04:05-03:00",
+  "elapsed_time": "00:12",
+  "total_success": 90,
+  "total_failed": 3,
+  "success_rate": 96.7741935483871,
+  "failure_rate": 3.225806451612903,
+  "fastest_time": "120ms",
+  "slowest_time": "2400ms",
+  "average_time": "640ms"
+}`
+
+func TestValidateTestResult(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		wantResult   bool
+		wantErrors   []string
+		wantWarnings []string
+	}{
+		{
+			name:       "valid",
+			data:       validResult,
+			wantResult: true,
+		},
+		{
+			name:         "legacy file without schema version",
+			data:         strings.Replace(validResult, `"schema_version": 1,`, "", 1),
+			wantResult:   true,
+			wantWarnings: []string{"no schema_version"},
+		},
+		{
+			name:       "unsupported schema version",
+			data:       strings.Replace(validResult, `"schema_version": 1`, `"schema_version": 99`, 1),
+			wantResult: true,
+			wantErrors: []string{"unsupported schema_version 99"},
+		},
+		{
+			name:       "invalid JSON",
+			data:       `{"total_requests": 93,`,
+			wantErrors: []string{"invalid JSON"},
+		},
+		{
+			name:       "wrong field type",
+			data:       strings.Replace(validResult, `"total_success": 90`, `"total_success": "90"`, 1),
+			wantErrors: []string{`field "total_success" has the wrong type`},
+		},
+		{
+			name:       "missing field",
+			data:       strings.Replace(validResult, `"average_time": "640ms"`, `"extra": true`, 1),
+			wantResult: true,
+			wantErrors: []string{`missing required field "average_time"`},
+			wantWarnings: []string{
+				`unknown field "extra"`,
+			},
+		},
+		{
+			name:       "inconsistent totals",
+			data:       strings.Replace(validResult, `"total_failed": 3`, `"total_failed": 4`, 1),
+			wantResult: true,
+			wantErrors: []string{"inconsistent totals"},
+			wantWarnings: []string{
+				"failure_rate 3.23 does not match",
+			},
+		},
+		{
+			name:       "unparseable time",
+			data:       strings.Replace(validResult, `"average_time": "640ms"`, `"average_time": "0.6s"`, 1),
+			wantResult: true,
+			wantErrors: []string{"average_time: time \"0.6s\" is not in the NNNms format"},
+		},
+		{
+			name:       "average outside range",
+			data:       strings.Replace(validResult, `"average_time": "640ms"`, `"average_time": "5000ms"`, 1),
+			wantResult: true,
+			wantErrors: []string{"average_time 5000ms is outside the fastest/slowest range"},
+		},
+		{
+			name: "suspicious values",
+			data: strings.NewReplacer(
+				`"fastest_time": "120ms"`, `"fastest_time": "0ms"`,
+				`"slowest_time": "2400ms"`, `"slowest_time": "90000ms"`,
+				`"average_time": "640ms"`, `"average_time": "0ms"`,
+			).Replace(validResult),
+			wantResult: true,
+			wantWarnings: []string{
+				"suspicious average_time of 0ms",
+				"suspicious slowest_time 90000ms",
+			},
+		},
+		{
+			name:       "request records disagree with totals",
+			data:       strings.Replace(validResult, `"average_time": "640ms"`, `"average_time": "640ms", "requests": [{"intent": "x", "success": true}]`, 1),
+			wantResult: true,
+			wantErrors: []string{
+				"1 request records for 93 total requests",
+				"1 successful request records for total_success 90",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, issues := validateTestResult("93", []byte(tt.data))
+
+			if (result != nil) != tt.wantResult {
+				t.Fatalf("expected result to be decoded: %v, got %v", tt.wantResult, result)
+			}
+
+			var errs, warnings []string
+			for _, issue := range issues {
+				if issue.Suite != "93" {
+					t.Errorf("expected issue for suite 93, got %q", issue.Suite)
+				}
+
+				switch issue.Severity {
+				case SeverityError:
+					errs = append(errs, issue.Message)
+				case SeverityWarning:
+					warnings = append(warnings, issue.Message)
+				default:
+					t.Errorf("unexpected severity %q", issue.Severity)
+				}
+			}
+
+			assertMessages(t, "errors", errs, tt.wantErrors)
+			assertMessages(t, "warnings", warnings, tt.wantWarnings)
+		})
+	}
+}
+
+func assertMessages(t *testing.T, kind string, got, want []string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d %s, got %d: %q", len(want), kind, len(got), got)
+	}
+
+	for i := range want {
+		if !strings.Contains(got[i], want[i]) {
+			t.Errorf("expected %s[%d] to contain %q, got %q", kind, i, want[i], got[i])
+		}
+	}
+}
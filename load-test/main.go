@@ -32,13 +32,26 @@ type (
 	}
 
 	Result struct {
-		Success bool
-		Record  CSVRecord
-		Error   string
-		Latency time.Duration
+		Success  bool
+		Record   CSVRecord
+		Returned ResponseData
+		Error    string
+		Latency  time.Duration
+	}
+
+	RequestRecord struct {
+		Intent       string `json:"intent"`
+		ExpectedID   int    `json:"expected_service_id"`
+		ExpectedName string `json:"expected_service_name"`
+		ReturnedID   int    `json:"returned_service_id"`
+		ReturnedName string `json:"returned_service_name"`
+		Success      bool   `json:"success"`
+		LatencyMs    int64  `json:"latency_ms"`
+		Error        string `json:"error,omitempty"`
 	}
 
 	OutputReport struct {
+		SchemaVersion int     `json:"schema_version"`
 		TotalRequests int     `json:"total_requests"`
 		Timestamp     string  `json:"timestamp"`
 		ElapsedTime   string  `json:"elapsed_time"`
@@ -49,10 +62,16 @@ type (
 		FastestTime   string  `json:"fastest_time,omitempty"`
 		SlowestTime   string  `json:"slowest_time,omitempty"`
 		AverageTime   string  `json:"average_time,omitempty"`
+
+		Requests []RequestRecord `json:"requests,omitempty"`
 	}
 )
 
 const (
+	// reportSchemaVersion must be bumped whenever OutputReport changes in a
+	// way the validator has to know about
+	reportSchemaVersion = 1
+
 	clientTimeout = 20 * time.Second
 	numWorkers    = 20
 )
@@ -112,8 +131,20 @@ func main() {
 	var failureCount int
 	var fastestTime, slowestTime time.Duration
 	var totalLatency time.Duration
+	requests := make([]RequestRecord, 0, len(records))
 
 	for result := range results {
+		requests = append(requests, RequestRecord{
+			Intent:       result.Record.Intent,
+			ExpectedID:   result.Record.ServiceID,
+			ExpectedName: result.Record.ServiceName,
+			ReturnedID:   result.Returned.ServiceID,
+			ReturnedName: result.Returned.ServiceName,
+			Success:      result.Success,
+			LatencyMs:    result.Latency.Milliseconds(),
+			Error:        result.Error,
+		})
+
 		if result.Success {
 			successCount++
 		} else {
@@ -136,6 +167,7 @@ func main() {
 	failureRate := float64(failureCount) / float64(total) * 100
 
 	report := OutputReport{
+		SchemaVersion: reportSchemaVersion,
 		TotalRequests: total,
 		ElapsedTime:   sw.FormatElapsed(),
 		Timestamp:     time.Now().Format(time.RFC3339),
@@ -146,6 +178,7 @@ func main() {
 		FastestTime:   fmt.Sprintf("%dms", fastestTime.Milliseconds()),
 		SlowestTime:   fmt.Sprintf("%dms", slowestTime.Milliseconds()),
 		AverageTime:   fmt.Sprintf("%dms", (totalLatency / time.Duration(total)).Milliseconds()),
+		Requests:      requests,
 	}
 
 	err = saveReportToFile(report, outputFile)
@@ -216,18 +249,28 @@ func worker(id int, client *http.Client, endpointURL string, jobs <-chan CSVReco
 
 		startTime := time.Now()
 
-		success := processRecord(client, endpointURL, record)
+		returned, err := processRecord(client, endpointURL, record)
 		elapsedTime := time.Since(startTime)
 
-		results <- Result{
-			Success: success,
-			Record:  record,
-			Latency: elapsedTime,
+		result := Result{
+			Success:  err == nil,
+			Record:   record,
+			Returned: returned,
+			Latency:  elapsedTime,
+		}
+		if err != nil {
+			result.Error = err.Error()
 		}
+
+		results <- result
 	}
 }
 
-func processRecord(client *http.Client, endpointURL string, record CSVRecord) bool {
+// processRecord sends a single intent to the endpoint and returns whatever
+// service the participant answered with. A non-nil error means the request
+// counts as a failure, either because it did not complete or because the
+// answer did not match the expected service.
+func processRecord(client *http.Client, endpointURL string, record CSVRecord) (ResponseData, error) {
 	payload := map[string]string{
 		"intent": record.Intent,
 	}
@@ -235,42 +278,42 @@ func processRecord(client *http.Client, endpointURL string, record CSVRecord) bo
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
 		fmt.Printf("Error marshaling payload: %v\n", err)
-		return false
+		return ResponseData{}, fmt.Errorf("marshaling payload: %w", err)
 	}
 
 	resp, err := client.Post(endpointURL, "application/json", bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		fmt.Printf("Error making request: %v\n", err)
-		return false
+		return ResponseData{}, fmt.Errorf("making request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		fmt.Printf("Error reading response: %v\n", err)
-		return false
+		return ResponseData{}, fmt.Errorf("reading response: %w", err)
 	}
 
 	var response Response
 	err = json.Unmarshal(body, &response)
 	if err != nil {
 		fmt.Printf("Error unmarshaling response: %v\n", err)
-		return false
+		return ResponseData{}, fmt.Errorf("unmarshaling response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		fmt.Printf("API error: %s\n", response.Error)
-		return false
+		return response.Data, fmt.Errorf("API error (status %d): %s", resp.StatusCode, response.Error)
 	}
 
 	if response.Data.ServiceID != record.ServiceID || response.Data.ServiceName != record.ServiceName {
 		fmt.Printf("Validation failed for intent %q - Expected: ID=%d, Name=%s | Got: ID=%d, Name=%s\n",
 			record.Intent, record.ServiceID, record.ServiceName, response.Data.ServiceID, response.Data.ServiceName)
-		return false
+		return response.Data, fmt.Errorf("wrong service: got ID=%d, Name=%s", response.Data.ServiceID, response.Data.ServiceName)
 	}
 
 	fmt.Printf("Success - ID=%d, Name=%s\n", response.Data.ServiceID, response.Data.ServiceName)
-	return true
+	return response.Data, nil
 }
 
 // Stopwatch struct
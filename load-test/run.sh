@@ -8,6 +8,41 @@ startContainer() {
     popd > /dev/null
 }
 
+# oomKilledServices prints how many of the participant's containers were
+# killed for exceeding the memory limit
+oomKilledServices() {
+    pushd ../participantes/$1 > /dev/null
+        docker compose ps -aq | xargs -r docker inspect -f '{{.State.OOMKilled}}' | grep -c true
+    popd > /dev/null
+}
+
+# writeRunnerReport records what the runner observed for the validator:
+# submission time, healthz outcome and resource limit violations
+writeRunnerReport() {
+    local directory=$1 healthz_ok=$2 oom_killed=$3
+    local submitted_at="null" violations=""
+
+    # the last commit by the participant: results and logs are committed by
+    # the runner and must not move the submission time
+    local commit_date=$(git log -1 --format=%cI -- "$directory" ":(exclude)$directory/results" ":(exclude)$directory/*.logs")
+    if [ -n "$commit_date" ]; then
+        submitted_at="\"$commit_date\""
+    fi
+
+    if [ "$oom_killed" -gt 0 ]; then
+        violations="\"$oom_killed container(s) OOM killed (128MB limit)\""
+    fi
+
+    cat > $directory/results/runner.json <<EOF
+{
+  "submitted_at": ${submitted_at},
+  "healthz_ok": ${healthz_ok},
+  "resource_violations": [${violations}],
+  "penalties": []
+}
+EOF
+}
+
 stopContainer() {
     pushd ../participantes/$1 > /dev/null
         docker compose down -v --remove-orphans
@@ -34,6 +69,9 @@ for directory in ../participantes/*; do
         mkdir -p "$directory/results"
     fi
 
+    # a healthz failure recorded by an earlier run must not outlive this one
+    rm -f "$directory/error.logs"
+
     echo "executing test for $participant..."
     stopContainer $participant
     startContainer $participant
@@ -53,13 +91,20 @@ for directory in ../participantes/*; do
         # echo "" > $directory/k6.logs
         # k6 run -e PARTICIPANT=$participant --log-output=file=$directory/k6.logs k6_runner.js
         echo "" > $directory/results/test.logs
-        
+
         echo "Running initial test for $participant..."
         go run main.go ../assets/intents_pre_loaded.csv http://localhost:18020/api/find-service $directory/results/93.json > $directory/results/test.logs 2>&1
 
         echo "Running extra test for $participant..."
         go run main.go ../assets/extra_intents.csv http://localhost:18020/api/find-service $directory/results/80.json > $directory/results/test.logs 2>&1    
 
+        # after the scored runs, so the checks neither warm caches nor spend
+        # the participant's credits before scoring; the validator shows the
+        # outcome as a badge
+        echo "Running contract checks for $participant..."
+        go -C .. run ./cmd/conformance -output "$(realpath $directory)/results/conformance.json" http://localhost:18020 > $directory/results/conformance.logs 2>&1
+
+        writeRunnerReport $directory true $(oomKilledServices $participant)
         stopContainer $participant
         echo "======================================="
         echo "working on $participant"
@@ -71,6 +116,7 @@ for directory in ../participantes/*; do
         # echo "log truncated at line 1000" >> $directory/docker-compose.logs
         # echo "log truncated at line 1000" >> $directory/k6.logs
     else
+        writeRunnerReport $directory false $(oomKilledServices $participant)
         stopContainer $participant
         echo "[$(date)] Seu backend não respondeu nenhuma das $max_attempts tentativas de GET para http://localhost:18020/api/healthz. Teste abortado." > $directory/error.logs
         echo "[$(date)] Inspecione o arquivo docker-compose.logs para mais informações." >> $directory/error.logs